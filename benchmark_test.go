@@ -0,0 +1,67 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/gildas/go-errors"
+)
+
+// Allocation budget of Error():
+//   - 0 allocation for sentinels without verbs, like errors.NotImplemented
+//   - 1 allocation for sentinels using %s or %v with a string What and Value, like errors.NotFound.With("user", "john")
+//   - errors with causes are rendered in a strings.Builder, with a few allocations for each cause
+
+func BenchmarkErrorWithoutVerbs(b *testing.B) {
+	err := errors.NotImplemented
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkErrorWithWhatAndValue(b *testing.B) {
+	err := errors.NotFound.With("user", "john")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkErrorWithIntValue(b *testing.B) {
+	err := errors.ArgumentInvalid.With("count", 12)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkErrorWithCause(b *testing.B) {
+	err := errors.CreationFailed.Wrap(errors.NotFound.With("user", "john"))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	err := errors.NotFound.With("user", "john")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fmt.Fprintf(io.Discard, "%v", err)
+	}
+}
+
+func BenchmarkFormatWithStack(b *testing.B) {
+	err := errors.NotFound.With("user", "john")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fmt.Fprintf(io.Discard, "%+v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package errors
+
+// Builder builds ad-hoc Errors, without declaring a sentinel first
+//
+// Example:
+//
+//	err := errors.Build().Code(http.StatusConflict).ID("error.user.duplicate").Text("User %s already exists").What(name).Err()
+type Builder struct {
+	final Error
+}
+
+// Build creates a new Builder
+func Build() *Builder {
+	return &Builder{}
+}
+
+// Code sets the Code of the Error
+func (builder *Builder) Code(code int) *Builder {
+	builder.final.Code = code
+	return builder
+}
+
+// ID sets the ID of the Error
+func (builder *Builder) ID(id string) *Builder {
+	builder.final.ID = id
+	return builder
+}
+
+// Text sets the Text of the Error
+func (builder *Builder) Text(text string) *Builder {
+	builder.final.Text = text
+	return builder
+}
+
+// What sets the What of the Error
+func (builder *Builder) What(what string) *Builder {
+	builder.final.What = what
+	return builder
+}
+
+// Value sets the Value of the Error
+func (builder *Builder) Value(value interface{}) *Builder {
+	builder.final.Value = value
+	return builder
+}
+
+// Severity sets the Severity of the Error
+func (builder *Builder) Severity(severity Severity) *Builder {
+	builder.final.Severity = severity
+	return builder
+}
+
+// Cause adds a cause to the Error
+//
+// If the Error already has a cause, the causes are collected in a MultiError.
+func (builder *Builder) Cause(cause error) *Builder {
+	builder.final = builder.final.WithCause(cause)
+	return builder
+}
+
+// Error returns the built Error
+//
+// Error also records the stack trace at the point it was called.
+//
+// The Builder can be reused to build other Errors.
+func (builder *Builder) Error() Error {
+	final := builder.final
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// Err returns the built Error as an error
+//
+// Err also records the stack trace at the point it was called.
+//
+// The Builder can be reused to build other Errors.
+func (builder *Builder) Err() error {
+	final := builder.final
+	final.initializeStack()
+	final.enrich()
+	return final
+}
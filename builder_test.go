@@ -0,0 +1,39 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanBuildError() {
+	requireStack(suite.T())
+	cause := errors.NotFound.With("user", "john")
+	err := errors.Build().
+		Code(http.StatusConflict).
+		ID("error.user.duplicate").
+		Text("User %s already exists (id: %v)").
+		What("john").
+		Value(12).
+		Severity(errors.SeverityWarning).
+		Cause(cause).
+		Error()
+	suite.Assert().Equal(http.StatusConflict, err.Code)
+	suite.Assert().Equal("error.user.duplicate", err.ID)
+	suite.Assert().Equal(errors.SeverityWarning, err.Severity)
+	suite.Assert().Equal("User john already exists (id: 12)\nCaused by:\n\tuser john Not Found", err.Error())
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Require().NotEmpty(err.Stack)
+	suite.Assert().Equal("(*ErrorsSuite).TestCanBuildError", fmt.Sprintf("%n", err.Stack[0]))
+}
+
+func (suite *ErrorsSuite) TestCanBuildErr() {
+	requireStack(suite.T())
+	builder := errors.Build().Code(http.StatusConflict).ID("error.user.duplicate").Text("User %s already exists")
+	err := builder.What("john").Err()
+	suite.Require().NotNil(err)
+	suite.Assert().Equal("User john already exists", err.Error())
+	suite.Assert().Equal("(*ErrorsSuite).TestCanBuildErr", fmt.Sprintf("%n", err.(errors.Error).Stack[0]))
+	suite.Assert().Equal("User jane already exists", builder.What("jane").Err().Error())
+}
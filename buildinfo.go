@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// BuildInfo describes the build that produced an Error
+type BuildInfo struct {
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	GoVersion string `json:"go,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+var (
+	buildInfo        *BuildInfo
+	buildInfoOnce    sync.Once
+	buildInfoEnabled atomic.Bool
+)
+
+// GetBuildInfo returns the build information of the current binary
+//
+// The information comes from runtime/debug.ReadBuildInfo and is read only once.
+//
+// If the binary was not built with module support, GetBuildInfo returns nil.
+func GetBuildInfo() *BuildInfo {
+	buildInfoOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		buildInfo = &BuildInfo{
+			Path:      info.Main.Path,
+			Version:   info.Main.Version,
+			GoVersion: info.GoVersion,
+		}
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				buildInfo.Revision = setting.Value
+			case "vcs.time":
+				buildInfo.Time = setting.Value
+			case "vcs.modified":
+				buildInfo.Modified = setting.Value == "true"
+			}
+		}
+	})
+	return buildInfo
+}
+
+// EnableBuildInfo tells if every created Error should carry the build information
+//
+// By default, errors do not carry the build information.
+func EnableBuildInfo(enable bool) {
+	buildInfoEnabled.Store(enable)
+}
+
+// WithBuildInfo creates a new Error from a given Error with the build information of the current binary.
+func (e Error) WithBuildInfo() Error {
+	final := e.derive()
+	final.Build = GetBuildInfo()
+	return final
+}
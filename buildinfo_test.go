@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"runtime"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanGetBuildInfo() {
+	info := errors.GetBuildInfo()
+	suite.Require().NotNil(info, "tests are built with module support")
+	suite.Assert().Equal(runtime.Version(), info.GoVersion)
+}
+
+func (suite *ErrorsSuite) TestCanAddBuildInfo() {
+	err := errors.NotImplemented.WithBuildInfo()
+	suite.Require().NotNil(err.Build, "err should have build info")
+	suite.Assert().Equal(errors.GetBuildInfo(), err.Build)
+	suite.Assert().Nil(errors.NotImplemented.Build, "NotImplemented should not have changed")
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().Nil(jerr)
+	var unmarshaled errors.Error
+	suite.Require().Nil(json.Unmarshal(payload, &unmarshaled))
+	suite.Require().NotNil(unmarshaled.Build)
+	suite.Assert().Equal(err.Build.GoVersion, unmarshaled.Build.GoVersion)
+}
+
+func (suite *ErrorsSuite) TestCanEnableBuildInfo() {
+	suite.Assert().Nil(errors.NotFound.With("key").(errors.Error).Build, "build info should not be stamped by default")
+
+	errors.EnableBuildInfo(true)
+	defer errors.EnableBuildInfo(false)
+	suite.Assert().NotNil(errors.NotFound.With("key").(errors.Error).Build, "build info should be stamped")
+}
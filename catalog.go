@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// CatalogEntry describes a registered sentinel
+type CatalogEntry struct {
+	ID        string   `json:"id"`
+	Code      int      `json:"code"`
+	Text      string   `json:"text"`
+	Severity  Severity `json:"severity"`
+	Retryable bool     `json:"retryable"`
+	DocURL    string   `json:"doc_url,omitempty"`
+}
+
+// Catalog returns the description of every registered sentinel, sorted by ID
+func Catalog() []CatalogEntry {
+	catalog := []CatalogEntry{}
+	for sentinel := range Sentinels() {
+		catalog = append(catalog, CatalogEntry{
+			ID:        sentinel.ID,
+			Code:      sentinel.Code,
+			Text:      sentinel.Text,
+			Severity:  Severity(sentinel.Severity.String()),
+			Retryable: sentinel.Retryable,
+			DocURL:    sentinel.DocURL,
+		})
+	}
+	slices.SortFunc(catalog, func(a, b CatalogEntry) int { return strings.Compare(a.ID, b.ID) })
+	return catalog
+}
+
+// WriteCatalog writes the Catalog in the given format to the given writer
+//
+// Supported formats are "json" and "markdown" (or "md").
+func WriteCatalog(writer io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return JSONMarshalError.Wrap(encoder.Encode(Catalog()))
+	case "markdown", "md":
+		_, _ = io.WriteString(writer, "| ID | Code | Message | Severity | Retryable |\n")
+		_, _ = io.WriteString(writer, "|----|------|---------|----------|-----------|\n")
+		for _, entry := range Catalog() {
+			id := "`" + entry.ID + "`"
+			if len(entry.DocURL) > 0 {
+				id = fmt.Sprintf("[%s](%s)", id, entry.DocURL)
+			}
+			text := strings.ReplaceAll(entry.Text, "|", `\|`)
+			if _, err := fmt.Fprintf(writer, "| %s | %d | %s | %s | %t |\n", id, entry.Code, text, entry.Severity, entry.Retryable); err != nil {
+				return WithStack(err)
+			}
+		}
+		return nil
+	default:
+		return Unsupported.With("format", format)
+	}
+}
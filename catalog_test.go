@@ -0,0 +1,62 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanGetCatalog() {
+	_ = errors.NewSentinel(503, "error.test.catalog.retry", "Try again", errors.Retryable(), errors.SeverityLevel(errors.SeverityWarning), errors.DocURL("https://acme.com/retry"))
+
+	catalog := errors.Catalog()
+	suite.Require().NotEmpty(catalog)
+	for i := 1; i < len(catalog); i++ {
+		suite.Assert().Less(catalog[i-1].ID, catalog[i].ID, "catalog should be sorted by ID")
+	}
+
+	var found bool
+	for _, entry := range catalog {
+		switch entry.ID {
+		case "error.test.catalog.retry":
+			found = true
+			suite.Assert().Equal(503, entry.Code)
+			suite.Assert().Equal("Try again", entry.Text)
+			suite.Assert().Equal(errors.SeverityWarning, entry.Severity)
+			suite.Assert().True(entry.Retryable)
+			suite.Assert().Equal("https://acme.com/retry", entry.DocURL)
+		case errors.NotFound.ID:
+			suite.Assert().Equal(errors.SeverityError, entry.Severity, "default severity should be error")
+			suite.Assert().False(entry.Retryable)
+		}
+	}
+	suite.Assert().True(found, "the test sentinel should be in the catalog")
+}
+
+func (suite *ErrorsSuite) TestCanWriteCatalogAsJSON() {
+	var output bytes.Buffer
+	suite.Require().Nil(errors.WriteCatalog(&output, "json"))
+
+	var catalog []errors.CatalogEntry
+	suite.Require().Nil(json.Unmarshal(output.Bytes(), &catalog))
+	suite.Assert().Equal(errors.Catalog(), catalog)
+}
+
+func (suite *ErrorsSuite) TestCanWriteCatalogAsMarkdown() {
+	var output bytes.Buffer
+	suite.Require().Nil(errors.WriteCatalog(&output, "markdown"))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	suite.Require().Len(lines, len(errors.Catalog())+2)
+	suite.Assert().Equal("| ID | Code | Message | Severity | Retryable |", lines[0])
+	suite.Assert().Contains(output.String(), "| `error.notfound` | 404 | %s %s Not Found | error | false |\n")
+}
+
+func (suite *ErrorsSuite) TestShouldFailWritingCatalogWithUnsupportedFormat() {
+	var output bytes.Buffer
+	err := errors.WriteCatalog(&output, "yaml")
+	suite.Assert().ErrorIs(err, errors.Unsupported)
+	suite.Assert().Equal("Unsupported format: yaml", err.Error())
+}
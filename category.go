@@ -0,0 +1,48 @@
+package errors
+
+import "strings"
+
+// IsCategory tells if any error in err's chain has an ID in the given category
+//
+// A category is a dotted prefix of IDs, like "error.argument" which contains
+// "error.argument.invalid", "error.argument.missing", etc.
+//
+// Example:
+//
+//	if errors.IsCategory(err, "error.argument") {
+//	  // do something with all argument errors
+//	}
+func IsCategory(err error, category string) bool {
+	return Is(err, categoryTarget(category))
+}
+
+// extends tells if this Error extends the sentinel with the given ID, see Extends
+func (e Error) extends(id string) bool {
+	for _, parent := range e.parents {
+		if parent == id {
+			return true
+		}
+	}
+	return false
+}
+
+// InCategory tells if this Error has an ID in the given category
+//
+// A category is a dotted prefix of IDs, like "error.argument".
+func (e Error) InCategory(category string) bool {
+	category = strings.TrimSuffix(category, ".")
+	if len(category) == 0 {
+		return false
+	}
+	return e.ID == category || strings.HasPrefix(e.ID, category+".")
+}
+
+// categoryTarget is used by IsCategory to match errors with Is
+type categoryTarget string
+
+// Error returns the string version of this categoryTarget
+//
+// implements error interface
+func (category categoryTarget) Error() string {
+	return string(category)
+}
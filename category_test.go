@@ -0,0 +1,33 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCheckCategory() {
+	suite.Assert().True(errors.ArgumentInvalid.InCategory("error.argument"))
+	suite.Assert().True(errors.ArgumentInvalid.InCategory("error.argument."))
+	suite.Assert().True(errors.ArgumentInvalid.InCategory("error"))
+	suite.Assert().True(errors.ArgumentInvalid.InCategory("error.argument.invalid"))
+	suite.Assert().False(errors.ArgumentInvalid.InCategory("error.arg"))
+	suite.Assert().False(errors.ArgumentInvalid.InCategory("error.http"))
+	suite.Assert().False(errors.ArgumentInvalid.InCategory(""))
+}
+
+func (suite *ErrorsSuite) TestCanCheckCategoryInChain() {
+	err := errors.WrapErrors(errors.NotFound.With("user", "john"), errors.ArgumentMissing.With("name"), fmt.Errorf("simple error"))
+	suite.Assert().True(errors.IsCategory(err, "error.argument"))
+	suite.Assert().True(errors.IsCategory(err, "error.notfound"))
+	suite.Assert().False(errors.IsCategory(err, "error.http"))
+	suite.Assert().False(errors.IsCategory(fmt.Errorf("simple error"), "error"))
+	suite.Assert().False(errors.IsCategory(nil, "error"))
+}
+
+func (suite *ErrorsSuite) TestCanCheckCategoryInMultiError() {
+	var errs errors.MultiError
+	errs.Append(errors.New("this is error 1"), errors.HTTPNotFound.WithStack())
+	suite.Assert().True(errors.IsCategory(errs.AsError(), "error.http"))
+	suite.Assert().False(errors.IsCategory(errs.AsError(), "error.argument"))
+}
@@ -0,0 +1,197 @@
+package errors
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"syscall"
+)
+
+// Classification tells if the operation that failed with an error can be attempted again
+type Classification string
+
+const (
+	// ClassificationUnknown is used for errors that cannot be classified, this is the default
+	ClassificationUnknown Classification = "unknown"
+	// ClassificationPermanent is used for errors that will happen again if the operation is attempted again
+	ClassificationPermanent Classification = "permanent"
+	// ClassificationTransient is used for errors that may not happen again if the operation is attempted again
+	ClassificationTransient Classification = "transient"
+)
+
+// String returns the string version of this Classification
+//
+// An empty Classification is ClassificationUnknown.
+//
+// implements fmt.Stringer
+func (classification Classification) String() string {
+	if len(classification) == 0 {
+		return string(ClassificationUnknown)
+	}
+	return string(classification)
+}
+
+// Classifier classifies errors
+//
+// A Classifier returns ClassificationUnknown for the errors it does not know, so the next Classifier is consulted.
+type Classifier interface {
+	Classify(err error) Classification
+}
+
+// ClassifierFunc is a func that implements Classifier
+type ClassifierFunc func(err error) Classification
+
+// Classify classifies the given error
+//
+// implements Classifier
+func (classifier ClassifierFunc) Classify(err error) Classification {
+	return classifier(err)
+}
+
+type namedClassifier struct {
+	name       string
+	classifier Classifier
+}
+
+var (
+	classifiers     []namedClassifier
+	classifiersLock sync.RWMutex
+)
+
+// RegisterClassifier registers a Classifier that Classify consults before its own rules
+//
+// The classifiers are consulted in the order they were registered.
+// If a Classifier was already registered with that name, it is replaced.
+func RegisterClassifier(name string, classifier Classifier) {
+	classifiersLock.Lock()
+	defer classifiersLock.Unlock()
+	for index, item := range classifiers {
+		if item.name == name {
+			classifiers[index].classifier = classifier
+			return
+		}
+	}
+	classifiers = append(classifiers, namedClassifier{name: name, classifier: classifier})
+}
+
+// UnregisterClassifier removes the Classifier registered with the given name
+func UnregisterClassifier(name string) {
+	classifiersLock.Lock()
+	defer classifiersLock.Unlock()
+	for index, item := range classifiers {
+		if item.name == name {
+			classifiers = append(classifiers[:index], classifiers[index+1:]...)
+			return
+		}
+	}
+}
+
+// registeredClassifiers returns a copy of the registered Classifier, so they can be called without holding the lock
+func registeredClassifiers() []namedClassifier {
+	classifiersLock.RLock()
+	defer classifiersLock.RUnlock()
+	return slices.Clone(classifiers)
+}
+
+// Classify tells if the operation that failed with the given error can be attempted again
+//
+// The registered Classifier are consulted first, see RegisterClassifier. Then:
+//
+//	context.DeadlineExceeded                         -> ClassificationTransient
+//	context.Canceled                                 -> ClassificationPermanent
+//
+// Otherwise, the first error of err's chain that can be classified gives the Classification:
+//
+//	Retryable Error                                  -> ClassificationTransient
+//	Error with Code 408, 425, 429, 502, 503, 504     -> ClassificationTransient
+//	Error with another 4xx Code, or 501, 505         -> ClassificationPermanent
+//	net.Error timeouts, temporary errors             -> ClassificationTransient
+//	*net.DNSError (not found)                        -> ClassificationPermanent
+//	ECONNREFUSED, ECONNRESET, EPIPE...               -> ClassificationTransient, see FromErrno
+//
+// If err is nil or cannot be classified, Classify returns ClassificationUnknown.
+//
+// Example:
+//
+//	if err := process(message); err != nil {
+//	  if errors.Classify(err) == errors.ClassificationTransient {
+//	    return message.Nack(true) // requeue
+//	  }
+//	  return message.Ack()
+//	}
+func Classify(err error) Classification {
+	if err == nil {
+		return ClassificationUnknown
+	}
+	for _, item := range registeredClassifiers() {
+		if classification := item.classifier.Classify(err); classification != ClassificationUnknown && len(classification) > 0 {
+			return classification
+		}
+	}
+	if Is(err, context.DeadlineExceeded) {
+		return ClassificationTransient
+	}
+	if Is(err, context.Canceled) {
+		return ClassificationPermanent
+	}
+	classification := ClassificationUnknown
+	Walk(err, func(cause error) bool {
+		classification = classify(cause)
+		return classification == ClassificationUnknown
+	})
+	return classification
+}
+
+// classify classifies the given error, without looking at its causes
+func classify(err error) Classification {
+	switch actual := err.(type) {
+	case Error:
+		return actual.classify()
+	case *Error:
+		if actual != nil {
+			return actual.classify()
+		}
+	case *net.DNSError:
+		if actual.IsNotFound {
+			return ClassificationPermanent
+		}
+		if actual.IsTimeout || actual.IsTemporary {
+			return ClassificationTransient
+		}
+	case syscall.Errno:
+		if sentinel, found := errnoSentinel(actual); found && sentinel.Retryable {
+			return ClassificationTransient
+		}
+		if actual.Timeout() || actual.Temporary() {
+			return ClassificationTransient
+		}
+	case net.Error:
+		if actual.Timeout() {
+			return ClassificationTransient
+		}
+	}
+	if temporary, ok := err.(interface{ Temporary() bool }); ok && temporary.Temporary() {
+		return ClassificationTransient
+	}
+	return ClassificationUnknown
+}
+
+// classify classifies this Error with its Retryable flag and its Code
+func (e Error) classify() Classification {
+	if e.Retryable {
+		return ClassificationTransient
+	}
+	switch e.Code {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ClassificationTransient
+	case http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+		return ClassificationPermanent
+	}
+	if e.Code >= 400 && e.Code < 500 {
+		return ClassificationPermanent
+	}
+	return ClassificationUnknown
+}
@@ -0,0 +1,67 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanClassifyErrors() {
+	suite.Assert().Equal(errors.ClassificationUnknown, errors.Classify(nil))
+	suite.Assert().Equal(errors.ClassificationUnknown, errors.Classify(fmt.Errorf("simple error")))
+	suite.Assert().Equal(errors.ClassificationPermanent, errors.Classify(errors.ArgumentMissing.With("name")))
+	suite.Assert().Equal(errors.ClassificationPermanent, errors.Classify(errors.NotImplemented.WithStack()))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(errors.HTTPServiceUnavailable.WithStack()))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(errors.RateLimitExceeded.With("/api")))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(errors.ConnectionReset.WithStack()), "retryable sentinels are transient")
+	suite.Assert().Equal(errors.ClassificationUnknown, errors.Classify(errors.CreationFailed.With("user")))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(errors.CreationFailed.Wrap(errors.HTTPBadGateway.WithStack())), "the causes should be classified")
+}
+
+func (suite *ErrorsSuite) TestCanClassifyContextAndNetErrors() {
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(fmt.Errorf("call: %w", context.DeadlineExceeded)))
+	suite.Assert().Equal(errors.ClassificationPermanent, errors.Classify(errors.WithStack(context.Canceled)))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}))
+	suite.Assert().Equal(errors.ClassificationPermanent, errors.Classify(&net.DNSError{Name: "nowhere.acme.com", IsNotFound: true}))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(&net.DNSError{Name: "acme.com", IsTimeout: true}))
+	suite.Assert().Equal(errors.ClassificationUnknown, errors.Classify(syscall.ENOENT))
+}
+
+func (suite *ErrorsSuite) TestCanRegisterClassifier() {
+	poison := fmt.Errorf("poison message")
+	errors.RegisterClassifier("test", errors.ClassifierFunc(func(err error) errors.Classification {
+		if errors.Is(err, poison) {
+			return errors.ClassificationPermanent
+		}
+		return errors.ClassificationUnknown
+	}))
+	defer errors.UnregisterClassifier("test")
+
+	suite.Assert().Equal(errors.ClassificationPermanent, errors.Classify(errors.WithStack(poison)))
+	suite.Assert().Equal(errors.ClassificationTransient, errors.Classify(errors.HTTPServiceUnavailable.WithStack()), "unknown errors should go to the next rules")
+
+	errors.UnregisterClassifier("test")
+	suite.Assert().Equal(errors.ClassificationUnknown, errors.Classify(errors.WithStack(poison)))
+	suite.Assert().Equal("unknown", errors.Classification("").String())
+}
+
+func (suite *ErrorsSuite) TestShouldNotHoldClassifierLockWhileClassifying() {
+	errors.RegisterClassifier("panicking", errors.ClassifierFunc(func(err error) errors.Classification {
+		panic("boom")
+	}))
+	suite.Assert().Panics(func() { _ = errors.Classify(fmt.Errorf("oops")) })
+	errors.UnregisterClassifier("panicking") // would block if the panic left the lock held
+
+	errors.RegisterClassifier("registering", errors.ClassifierFunc(func(err error) errors.Classification {
+		errors.RegisterClassifier("registered", errors.ClassifierFunc(func(err error) errors.Classification {
+			return errors.ClassificationUnknown
+		}))
+		return errors.ClassificationPermanent
+	}))
+	defer errors.UnregisterClassifier("registering")
+	defer errors.UnregisterClassifier("registered")
+	suite.Assert().Equal(errors.ClassificationPermanent, errors.Classify(fmt.Errorf("oops")))
+}
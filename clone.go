@@ -0,0 +1,134 @@
+package errors
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// CloneDeep creates a copy of this Error that shares nothing with it
+//
+// Unlike Clone, the Stack, the Attributes, the Extensions, the Value, and the causes are copied as well,
+// so modifying the copy does not modify this Error.
+//
+// Causes that are not Error or MultiError cannot be copied and are shared.
+// In the Value and the Attributes, maps and slices are copied, pointers are shared.
+func (e Error) CloneDeep() *Error {
+	final := e.cloneDeep(map[error]error{})
+	return &final
+}
+
+// cloneDeep copies this Error, clones contains the pointer errors that were already copied
+func (e Error) cloneDeep(clones map[error]error) Error {
+	final := e.derive()
+	if e.Stack != nil {
+		final.Stack = append(make(StackTrace, 0, len(e.Stack)), e.Stack...)
+	}
+	if e.Attributes != nil {
+		final.Attributes = make(map[string]interface{}, len(e.Attributes))
+		for key, value := range e.Attributes {
+			final.Attributes[key] = cloneValue(value)
+		}
+	}
+	if e.Extensions != nil {
+		final.Extensions = make(map[string]json.RawMessage, len(e.Extensions))
+		for key, value := range e.Extensions {
+			final.Extensions[key] = slices.Clone(value)
+		}
+	}
+	if e.Service != nil {
+		service := *e.Service
+		final.Service = &service
+	}
+	if e.Build != nil {
+		build := *e.Build
+		final.Build = &build
+	}
+	if e.RateLimit != nil {
+		limit := *e.RateLimit
+		final.RateLimit = &limit
+	}
+	if e.Range != nil {
+		allowed := Range{Min: cloneValue(e.Range.Min), Max: cloneValue(e.Range.Max)}
+		final.Range = &allowed
+	}
+	final.Value = cloneValue(e.Value)
+	final.Cause = cloneError(e.Cause, clones)
+	return final
+}
+
+// cloneError copies err if it is an Error or a MultiError, clones contains the pointer errors that were already copied
+func cloneError(err error, clones map[error]error) error {
+	if isPointer(err) {
+		if clone, found := clones[err]; found {
+			return clone
+		}
+	}
+	switch actual := err.(type) {
+	case Error:
+		return actual.cloneDeep(clones)
+	case *Error:
+		if actual == nil {
+			return err
+		}
+		final := &Error{}
+		clones[err] = final
+		*final = actual.cloneDeep(clones)
+		return final
+	case *MultiError:
+		if actual == nil {
+			return err
+		}
+		final := &MultiError{}
+		clones[err] = final
+		if actual.Errors != nil {
+			final.Errors = make([]error, 0, len(actual.Errors))
+			for _, member := range actual.Errors {
+				final.Errors = append(final.Errors, cloneError(member, clones))
+			}
+		}
+		return final
+	}
+	return err
+}
+
+// cloneValue copies the maps and slices in the given value
+func cloneValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return cloneReflectValue(reflect.ValueOf(value)).Interface()
+}
+
+// cloneReflectValue copies the maps and slices in the given reflect.Value
+func cloneReflectValue(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		final := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			final.SetMapIndex(iter.Key(), cloneReflectValue(iter.Value()))
+		}
+		return final
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		final := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			final.Index(i).Set(cloneReflectValue(value.Index(i)))
+		}
+		return final
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		final := reflect.New(value.Type()).Elem()
+		final.Set(cloneReflectValue(value.Elem()))
+		return final
+	}
+	return value
+}
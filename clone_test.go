@@ -0,0 +1,54 @@
+package errors_test
+
+import (
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCloneDeep() {
+	requireStack(suite.T())
+	inner := errors.NotFound.With("user", "john").(errors.Error).WithField("tags", []string{"a", "b"})
+	original := errors.ArgumentInvalid.With("ids", map[string]interface{}{"list": []int{1, 2}}).(errors.Error).
+		WithField("tenant", "acme").
+		WithCause(inner)
+
+	clone := original.CloneDeep()
+	suite.Require().Equal(original, *clone)
+
+	clone.Stack[0] = errors.StackFrame(0)
+	clone.Attributes["tenant"] = "other"
+	clone.Value.(map[string]interface{})["list"].([]int)[0] = 42
+	cause := clone.Cause.(errors.Error)
+	cause.Attributes["tags"].([]string)[0] = "z"
+
+	suite.Assert().NotEqual(errors.StackFrame(0), original.Stack[0])
+	suite.Assert().Equal("acme", original.Attributes["tenant"])
+	suite.Assert().Equal(1, original.Value.(map[string]interface{})["list"].([]int)[0])
+	suite.Assert().Equal("a", inner.Attributes["tags"].([]string)[0])
+}
+
+func (suite *ErrorsSuite) TestShouldShareSlicesWithShallowClone() {
+	requireStack(suite.T())
+	original := errors.NotFound.With("user", "john").(errors.Error)
+	clone := original.Clone()
+	clone.Stack[0] = errors.StackFrame(0)
+	suite.Assert().Equal(errors.StackFrame(0), original.Stack[0])
+}
+
+func (suite *ErrorsSuite) TestCanCloneDeepMultipleCauses() {
+	original := errors.CreationFailed.WithCause(errors.ArgumentMissing.With("name")).WithCause(errors.NotFound.With("user", "john"))
+	clone := original.CloneDeep()
+	suite.Require().Equal(original.Error(), clone.Error())
+	clone.Cause.(*errors.MultiError).Errors[0] = errors.Timeout
+	suite.Assert().Contains(original.Error(), "Argument name is missing")
+}
+
+func (suite *ErrorsSuite) TestCanCloneDeepWithCycle() {
+	original := errors.NotFound.Clone()
+	original.Cause = original
+	clone := original.CloneDeep()
+	suite.Require().NotSame(original, clone)
+	cause, ok := clone.Cause.(*errors.Error)
+	suite.Require().True(ok, "Cause should be an *errors.Error")
+	suite.Assert().NotSame(original, cause)
+	suite.Assert().Same(cause, cause.Cause)
+}
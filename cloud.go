@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// FromCloud converts an error from a cloud provider SDK into an Error
+//
+// The following errors are recognized in err's chain:
+//
+//	AWS:    smithy.APIError, and the HTTP status of *awshttp.ResponseError
+//	Google: *googleapi.Error, and *apierror.APIError
+//
+// The common cases are mapped to the following sentinels:
+//
+//	Throttling, rate limits, quotas, HTTP 429   -> RateLimitExceeded (retryable)
+//	Access denied, HTTP 403                     -> PermissionDenied
+//	Not found, no such key or bucket, HTTP 404  -> NotFound
+//
+// The other errors become a RuntimeError with the HTTP status of the provider as Code, if any.
+//
+// The "provider" attribute contains "aws" or "google", the "provider_code" and "provider_message" attributes
+// contain the error code and the message of the provider.
+//
+// The given error becomes the Cause. Errors that do not come from a cloud provider are annotated with a stack trace,
+// and Errors are returned as is.
+//
+// If err is nil, FromCloud returns nil.
+//
+// FromCloud also records the stack trace at the point it was called.
+//
+// Example:
+//
+//	if _, err := client.GetObject(ctx, input); err != nil {
+//	  return errors.FromCloud(err) // errors.Is(err, errors.NotFound) is true for NoSuchKey
+//	}
+func FromCloud(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	details, found := cloudDetailsOf(err)
+	if !found {
+		return WithStack(err)
+	}
+	var final Error
+	code := strings.ToLower(details.code)
+	switch {
+	case details.status == http.StatusTooManyRequests || containsAny(code, "throttl", "ratelimit", "toomanyrequests", "slowdown", "quota", "limitexceeded"):
+		final = RateLimitExceeded.derive()
+		final.What = details.provider
+	case details.status == http.StatusForbidden || containsAny(code, "accessdenied", "forbidden", "unauthorizedoperation", "permissiondenied"):
+		final = PermissionDenied.derive()
+		final.What = details.provider
+	case details.status == http.StatusNotFound || containsAny(code, "notfound", "nosuch"):
+		final = NotFound.derive()
+		final.What = "Resource"
+		final.Value = details.code
+	default:
+		final = RuntimeError.derive()
+		if details.status > 0 {
+			final.Code = details.status
+		}
+	}
+	attributes := map[string]interface{}{"provider": details.provider}
+	if len(details.code) > 0 {
+		attributes["provider_code"] = details.code
+	}
+	if len(details.message) > 0 {
+		attributes["provider_message"] = details.message
+	}
+	final.Attributes = attributes
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// cloudDetails contains what FromCloud needs from the errors of cloud providers
+type cloudDetails struct {
+	provider string
+	code     string
+	message  string
+	status   int
+}
+
+// cloudDetailsOf collects the cloudDetails of err's chain
+func cloudDetailsOf(err error) (details cloudDetails, found bool) {
+	Walk(err, func(err error) bool {
+		switch actual := err.(type) {
+		case interface {
+			ErrorCode() string
+			ErrorMessage() string
+		}: // smithy.APIError
+			details.provider = "aws"
+			details.code = actual.ErrorCode()
+			details.message = actual.ErrorMessage()
+			found = true
+		case interface {
+			Reason() string
+			HTTPCode() int
+		}: // apierror.APIError
+			details.provider = "google"
+			details.code = actual.Reason()
+			details.status = actual.HTTPCode()
+			found = true
+			return false
+		case interface{ HTTPStatusCode() int }: // awshttp.ResponseError
+			if details.status == 0 {
+				details.status = actual.HTTPStatusCode()
+			}
+		default:
+			if google, ok := googleAPIDetails(err); ok {
+				details = google
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return
+}
+
+// googleAPIDetails gives the cloudDetails of a *googleapi.Error, which has no methods to get them
+//
+// The error is recognized by its fields: Code, Message, and Errors, which contain a Reason.
+func googleAPIDetails(err error) (cloudDetails, bool) {
+	value := reflect.ValueOf(err)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return cloudDetails{}, false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return cloudDetails{}, false
+	}
+	code := value.FieldByName("Code")
+	message := value.FieldByName("Message")
+	items := value.FieldByName("Errors")
+	if !code.IsValid() || code.Kind() != reflect.Int || !message.IsValid() || message.Kind() != reflect.String || !items.IsValid() || items.Kind() != reflect.Slice {
+		return cloudDetails{}, false
+	}
+	details := cloudDetails{provider: "google", message: message.String(), status: int(code.Int())}
+	if items.Len() > 0 {
+		if item := reflect.Indirect(items.Index(0)); item.Kind() == reflect.Struct {
+			if reason := item.FieldByName("Reason"); reason.IsValid() && reason.Kind() == reflect.String {
+				details.code = reason.String()
+			}
+		}
+	}
+	return details, true
+}
+
+// containsAny tells if the given text contains any of the given parts
+func containsAny(text string, parts ...string) bool {
+	for _, part := range parts {
+		if strings.Contains(text, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+// smithyError mimics smithy.GenericAPIError
+type smithyError struct {
+	Code    string
+	Message string
+}
+
+func (err *smithyError) Error() string        { return "api error " + err.Code + ": " + err.Message }
+func (err *smithyError) ErrorCode() string    { return err.Code }
+func (err *smithyError) ErrorMessage() string { return err.Message }
+
+// responseError mimics awshttp.ResponseError
+type responseError struct {
+	status int
+	err    error
+}
+
+func (err *responseError) Error() string {
+	return fmt.Sprintf("https response error StatusCode: %d, %s", err.status, err.err)
+}
+func (err *responseError) Unwrap() error       { return err.err }
+func (err *responseError) HTTPStatusCode() int { return err.status }
+
+// googleError mimics googleapi.Error
+type googleError struct {
+	Code    int
+	Message string
+	Errors  []googleErrorItem
+}
+
+type googleErrorItem struct {
+	Reason  string
+	Message string
+}
+
+func (err *googleError) Error() string {
+	return fmt.Sprintf("googleapi: Error %d: %s", err.Code, err.Message)
+}
+
+func (suite *ErrorsSuite) TestCanConvertAWSErrors() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromCloud(nil))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromCloud(pointer), "a *Error should be returned as is")
+
+	err := errors.FromCloud(fmt.Errorf("operation error S3: GetObject, %w", &responseError{status: 404, err: &smithyError{Code: "NoSuchKey", Message: "The specified key does not exist."}}))
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("aws", details.Attributes["provider"])
+	suite.Assert().Equal("NoSuchKey", details.Attributes["provider_code"])
+	suite.Assert().Equal("The specified key does not exist.", details.Attributes["provider_message"])
+	suite.Assert().NotEmpty(details.Stack)
+
+	err = errors.FromCloud(&smithyError{Code: "ThrottlingException", Message: "Rate exceeded"})
+	suite.Assert().ErrorIs(err, errors.RateLimitExceeded)
+	suite.Assert().True(err.(errors.Error).Retryable)
+
+	err = errors.FromCloud(&smithyError{Code: "AccessDenied", Message: "Access Denied"})
+	suite.Assert().ErrorIs(err, errors.PermissionDenied)
+
+	err = errors.FromCloud(&responseError{status: 502, err: &smithyError{Code: "InternalError"}})
+	suite.Assert().ErrorIs(err, errors.RuntimeError)
+	suite.Assert().Equal(502, err.(errors.Error).Code)
+}
+
+func (suite *ErrorsSuite) TestCanConvertGoogleErrors() {
+	err := errors.FromCloud(&googleError{Code: 429, Message: "Quota exceeded", Errors: []googleErrorItem{{Reason: "rateLimitExceeded"}}})
+	suite.Assert().ErrorIs(err, errors.RateLimitExceeded)
+	suite.Assert().Equal("google", err.(errors.Error).Attributes["provider"])
+	suite.Assert().Equal("rateLimitExceeded", err.(errors.Error).Attributes["provider_code"])
+
+	err = errors.FromCloud(fmt.Errorf("get: %w", &googleError{Code: 403, Message: "Forbidden"}))
+	suite.Assert().ErrorIs(err, errors.PermissionDenied)
+
+	err = errors.FromCloud(fmt.Errorf("not a cloud error"))
+	suite.Assert().Equal("error.runtime", err.(errors.Error).ID)
+	suite.Assert().Nil(err.(errors.Error).Attributes)
+}
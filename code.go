@@ -0,0 +1,24 @@
+package errors
+
+import "strconv"
+
+// IsCode tells if any error in err's chain is an Error with the given Code
+//
+// Example:
+//
+//	if errors.IsCode(err, http.StatusNotFound) {
+//	  // do something with all 404 errors
+//	}
+func IsCode(err error, code int) bool {
+	return Is(err, codeTarget(code))
+}
+
+// codeTarget is used by IsCode to match errors with Is
+type codeTarget int
+
+// Error returns the string version of this codeTarget
+//
+// implements error interface
+func (code codeTarget) Error() string {
+	return "code " + strconv.Itoa(int(code))
+}
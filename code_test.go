@@ -0,0 +1,28 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCheckCode() {
+	err := errors.WrapErrors(errors.ArgumentMissing.With("name"), errors.NotFound.With("user", "john"), fmt.Errorf("simple error"))
+	suite.Assert().True(errors.IsCode(err, http.StatusBadRequest))
+	suite.Assert().True(errors.IsCode(err, http.StatusNotFound))
+	suite.Assert().False(errors.IsCode(err, http.StatusConflict))
+	suite.Assert().False(errors.IsCode(fmt.Errorf("simple error"), http.StatusInternalServerError))
+
+	var errs errors.MultiError
+	errs.Append(fmt.Errorf("simple error"), errors.HTTPStatusConflict.WithStack())
+	suite.Assert().True(errors.IsCode(errs.AsError(), http.StatusConflict))
+}
+
+func (suite *ErrorsSuite) TestCanMatchSentinelByCode() {
+	anyNotFound := errors.NewSentinel(http.StatusNotFound, "error.test.any.notfound", "Not Found", errors.MatchCode())
+	suite.Assert().ErrorIs(errors.HTTPNotFound.WithStack(), anyNotFound)
+	suite.Assert().ErrorIs(errors.NotFound.With("user", "john"), &anyNotFound)
+	suite.Assert().NotErrorIs(errors.ArgumentMissing.With("name"), anyNotFound)
+	suite.Assert().NotErrorIs(anyNotFound.WithStack(), errors.HTTPNotFound, "only the sentinel with MatchCode should match by code")
+}
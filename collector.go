@@ -0,0 +1,77 @@
+package errors
+
+// Collector is used to collect errors and warnings separately, like in a linter
+//
+// The zero value is ready to use.
+//
+// Example:
+//
+//	collector := errors.Collector{}
+//	for _, rule := range rules {
+//	  collector.Append(rule.Check(document))
+//	}
+//	for _, warning := range collector.Warnings().Errors {
+//	  log.Warnf("%s", warning)
+//	}
+//	return collector.AsError()
+type Collector struct {
+	warnings MultiError
+	errors   MultiError
+}
+
+// Append appends new errors to the bucket that matches their Severity
+//
+// Errors with SeverityInfo or SeverityWarning go to the warnings, the others go to the errors.
+//
+// If an error is nil, it is not added
+func (collector *Collector) Append(errs ...error) {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var details Error
+		if As(err, &details) && (details.Severity == SeverityInfo || details.Severity == SeverityWarning) {
+			collector.warnings.Append(err)
+		} else {
+			collector.errors.Append(err)
+		}
+	}
+}
+
+// AppendWarning appends new warnings, regardless of their Severity
+//
+// If an error is nil, it is not added
+func (collector *Collector) AppendWarning(errs ...error) {
+	collector.warnings.Append(errs...)
+}
+
+// AppendError appends new errors, regardless of their Severity
+//
+// If an error is nil, it is not added
+func (collector *Collector) AppendError(errs ...error) {
+	collector.errors.Append(errs...)
+}
+
+// Warnings returns the warnings collected so far
+func (collector *Collector) Warnings() *MultiError {
+	return &collector.warnings
+}
+
+// Errors returns the errors collected so far
+func (collector *Collector) Errors() *MultiError {
+	return &collector.errors
+}
+
+// IsEmpty returns true if this Collector contains no errors and no warnings
+func (collector *Collector) IsEmpty() bool {
+	return collector.warnings.IsEmpty() && collector.errors.IsEmpty()
+}
+
+// AsError returns the collected errors as an error, nil if there are none
+//
+// The warnings are ignored, see MultiError.AsError.
+//
+// AsError also records the stack trace at the point it was called.
+func (collector *Collector) AsError() error {
+	return collector.errors.AsError()
+}
@@ -0,0 +1,43 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCollectWarningsAndErrors() {
+	collector := errors.Collector{}
+	suite.Assert().True(collector.IsEmpty())
+	suite.Assert().Nil(collector.AsError())
+
+	collector.Append(
+		errors.ArgumentInvalid.WithSeverity(errors.SeverityWarning).With("indent", 3),
+		nil,
+		errors.ArgumentMissing.WithSeverity(errors.SeverityInfo).With("name"),
+		errors.ArgumentMissing.With("id"),
+		fmt.Errorf("simple error"),
+	)
+	collector.AppendWarning(fmt.Errorf("deprecated syntax"))
+	suite.Assert().False(collector.IsEmpty())
+	suite.Assert().Len(collector.Warnings().Errors, 3)
+	suite.Assert().Len(collector.Errors().Errors, 2)
+
+	err := collector.AsError()
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+	suite.Assert().NotErrorIs(err, errors.ArgumentInvalid, "warnings should not be part of the error")
+}
+
+func (suite *ErrorsSuite) TestCollectorWithOnlyWarningsShouldNotFail() {
+	collector := errors.Collector{}
+	collector.AppendWarning(errors.ArgumentInvalid.With("indent", 3))
+	collector.Append(errors.ArgumentMissing.WithSeverity(errors.SeverityWarning).With("name"))
+	suite.Assert().False(collector.IsEmpty())
+	suite.Assert().True(collector.Errors().IsEmpty())
+	suite.Assert().Len(collector.Warnings().Errors, 2)
+	suite.Assert().Nil(collector.AsError())
+
+	collector.AppendError(errors.ArgumentInvalid.WithSeverity(errors.SeverityWarning).With("indent", 3))
+	suite.Assert().ErrorIs(collector.AsError(), errors.ArgumentInvalid, "AppendError should ignore the severity")
+}
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"io/fs"
+	"regexp"
+	"strconv"
+)
+
+// ConfigOption is an option for FromConfig
+type ConfigOption func(*configOptions)
+
+type configOptions struct {
+	file string
+	line int
+}
+
+// ConfigSource tells FromConfig where the offending key was found
+//
+// If line is 0 or less, only the file is recorded.
+func ConfigSource(file string, line int) ConfigOption {
+	return func(options *configOptions) {
+		options.file = file
+		options.line = line
+	}
+}
+
+// parserLine matches the line reported by most configuration parsers, like: "yaml: line 3: did not find expected key"
+var parserLine = regexp.MustCompile(`\bline (\d+)\b`)
+
+// FromConfig creates an Error about the configuration key with the given value
+//
+// The Error depends on the value:
+//
+//	nil       -> ConfigMissing
+//	error     -> ConfigParseFailed, with the error as its Cause
+//	otherwise -> ConfigInvalid, with the expected value in the "expected" attribute (if not nil)
+//
+// The key goes to the What of the Error and to the "key" attribute.
+//
+// The file and line of the key go to the "file" and "line" attributes. They are given with ConfigSource,
+// otherwise, when the value is an error, they are taken from its *fs.PathError and from its message
+// (most parsers report errors like "yaml: line 3: ...").
+//
+// FromConfig also records the stack trace at the point it was called.
+//
+// Example:
+//
+//	if port, err := strconv.Atoi(os.Getenv("PORT")); err != nil || port <= 0 {
+//	  return errors.FromConfig("PORT", os.Getenv("PORT"), "a positive integer", errors.ConfigSource(".env", 0))
+//	}
+func FromConfig(key string, value, expected interface{}, options ...ConfigOption) error {
+	source := configOptions{}
+	var final Error
+
+	switch actual := value.(type) {
+	case nil:
+		final = ConfigMissing.derive()
+	case error:
+		final = ConfigParseFailed.derive()
+		final.Cause = actual
+		var pathError *fs.PathError
+		if As(actual, &pathError) {
+			source.file = pathError.Path
+		}
+		if match := parserLine.FindStringSubmatch(actual.Error()); match != nil {
+			source.line, _ = strconv.Atoi(match[1])
+		}
+	default:
+		final = ConfigInvalid.derive()
+		final.Value = value
+	}
+	for _, option := range options {
+		option(&source)
+	}
+	final.What = key
+	final.Attributes = map[string]interface{}{"key": key}
+	if expected != nil && final.ID == ConfigInvalid.ID {
+		final.Attributes["expected"] = expected
+	}
+	if len(source.file) > 0 {
+		final.Attributes["file"] = source.file
+	}
+	if source.line > 0 {
+		final.Attributes["line"] = source.line
+	}
+	final.initializeStack()
+	final.enrich()
+	return final
+}
@@ -0,0 +1,44 @@
+package errors_test
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCreateConfigErrors() {
+	requireStack(suite.T())
+	err := errors.FromConfig("database.url", nil, nil)
+	suite.Assert().ErrorIs(err, errors.ConfigMissing)
+	suite.Assert().Equal("Configuration database.url is missing", err.Error())
+
+	err = errors.FromConfig("PORT", "-1", "a positive integer", errors.ConfigSource(".env", 3))
+	suite.Require().ErrorIs(err, errors.ConfigInvalid)
+	suite.Assert().Equal("Configuration PORT is invalid (value: -1)", err.Error())
+	var details errors.Error
+	suite.Require().ErrorAs(err, &details)
+	suite.Assert().Equal("PORT", details.Attributes["key"])
+	suite.Assert().Equal("a positive integer", details.Attributes["expected"])
+	suite.Assert().Equal(".env", details.Attributes["file"])
+	suite.Assert().Equal(3, details.Attributes["line"])
+	suite.Assert().NotEmpty(details.Stack)
+}
+
+func (suite *ErrorsSuite) TestCanCreateConfigParseError() {
+	parseErr := fmt.Errorf("config.yaml: %w", &fs.PathError{Op: "parse", Path: "config.yaml", Err: fmt.Errorf("yaml: line 12: did not find expected key")})
+	err := errors.FromConfig("server", parseErr, nil)
+	suite.Require().ErrorIs(err, errors.ConfigParseFailed)
+	suite.Assert().ErrorIs(err, parseErr)
+	var details errors.Error
+	suite.Require().ErrorAs(err, &details)
+	suite.Assert().Equal("config.yaml", details.Attributes["file"])
+	suite.Assert().Equal(12, details.Attributes["line"])
+	suite.Assert().NotContains(details.Attributes, "expected")
+}
+
+func (suite *ErrorsSuite) TestCanCreateFeatureDisabledError() {
+	err := errors.FeatureDisabled.With("beta-search")
+	suite.Assert().ErrorIs(err, errors.FeatureDisabled)
+	suite.Assert().Equal("Feature beta-search is disabled", err.Error())
+}
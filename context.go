@@ -0,0 +1,260 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CauseFromContext returns the reason why the given context is done.
+//
+// context.Cause(ctx) is preferred over ctx.Err() as it carries more information.
+//
+// If the context is not done, CauseFromContext returns nil.
+//
+// CauseFromContext also records the stack trace at the point it was called.
+func CauseFromContext(ctx context.Context) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	if cause := context.Cause(ctx); cause != nil {
+		err = cause
+	}
+	if _err, ok := err.(contextError); ok {
+		err = _err.cause
+	}
+	return WithStack(err)
+}
+
+// WithCancelCause returns a copy of parent that can be canceled with a cause.
+//
+// Unlike context.WithCancelCause, the cause is also returned by ctx.Err()
+// of the returned context and of all the contexts derived from it.
+// The returned error still matches context.Canceled.
+//
+// Example:
+//
+//	ctx, cancel := errors.WithCancelCause(context.Background())
+//	cancel(errors.NotFound.With("user", "john"))
+//	if errors.Is(ctx.Err(), errors.NotFound) {
+//	  // do something
+//	}
+func WithCancelCause(parent context.Context) (context.Context, context.CancelCauseFunc) {
+	inner, cancel := context.WithCancelCause(parent)
+	ctx := &cancelCauseContext{Context: inner, done: make(chan struct{})}
+	context.AfterFunc(inner, func() { close(ctx.done) })
+	return ctx, cancel
+}
+
+// cancelCauseContext is a context whose Err() returns the cancel cause
+//
+// It has its own Done channel so the contexts derived from it
+// get their error from its Err() method.
+type cancelCauseContext struct {
+	context.Context
+	done chan struct{}
+}
+
+// Done returns a channel that is closed when this context is done
+//
+// implements context.Context
+func (ctx *cancelCauseContext) Done() <-chan struct{} {
+	return ctx.done
+}
+
+// Err returns the cause of this context being done, if any
+//
+// implements context.Context
+func (ctx *cancelCauseContext) Err() error {
+	select {
+	case <-ctx.done:
+	default:
+		return nil
+	}
+	err := ctx.Context.Err()
+	cause := context.Cause(ctx.Context)
+	if cause == nil || cause == err {
+		return err
+	}
+	return contextError{cause: cause, err: err}
+}
+
+// AfterFunc arranges to call f after this context is done
+//
+// This allows derived contexts to be canceled with this context's Err()
+func (ctx *cancelCauseContext) AfterFunc(f func()) func() bool {
+	return context.AfterFunc(ctx.Context, func() {
+		<-ctx.done // make sure Err() is ready
+		f()
+	})
+}
+
+// contextError is the error returned by cancelCauseContext.Err()
+//
+// It matches both the cause and the context error.
+type contextError struct {
+	cause error
+	err   error
+}
+
+// Error returns the string version of this error
+//
+// implements error interface
+func (e contextError) Error() string {
+	return e.cause.Error()
+}
+
+// Unwrap gives the cause and the context error
+//
+// implements errors.Unwrap interface (package "errors").
+func (e contextError) Unwrap() []error {
+	return []error{e.cause, e.err}
+}
+
+// ContextKey is the type of the keys used to store request-scoped values in a context.Context
+//
+// These values are copied into errors by WithContext.
+type ContextKey string
+
+const (
+	// CorrelationIDContextKey is the context key of the Correlation ID (or Request ID)
+	CorrelationIDContextKey ContextKey = "correlation_id"
+	// TenantContextKey is the context key of the Tenant
+	TenantContextKey ContextKey = "tenant"
+	// UserContextKey is the context key of the User
+	UserContextKey ContextKey = "user"
+	// TraceIDContextKey is the context key of the Trace ID
+	TraceIDContextKey ContextKey = "trace_id"
+)
+
+// ContextExtractor extracts a value from a context.Context
+//
+// The extractor returns false if the context does not contain the value.
+type ContextExtractor func(ctx context.Context) (interface{}, bool)
+
+var (
+	contextExtractors     = map[string]ContextExtractor{}
+	contextExtractorsLock sync.RWMutex
+)
+
+func init() {
+	for _, key := range []ContextKey{CorrelationIDContextKey, TenantContextKey, UserContextKey, TraceIDContextKey} {
+		RegisterContextExtractor(string(key), ValueFromContext(key))
+	}
+}
+
+// RegisterContextExtractor registers an extractor that WithContext will use to fill the attribute with the given key
+//
+// If an extractor was already registered with that key, it is replaced.
+func RegisterContextExtractor(key string, extractor ContextExtractor) {
+	contextExtractorsLock.Lock()
+	defer contextExtractorsLock.Unlock()
+	contextExtractors[key] = extractor
+}
+
+// UnregisterContextExtractor removes the extractor registered with the given key
+func UnregisterContextExtractor(key string) {
+	contextExtractorsLock.Lock()
+	defer contextExtractorsLock.Unlock()
+	delete(contextExtractors, key)
+}
+
+// ValueFromContext returns a ContextExtractor that fetches the value stored in a context.Context with the given key
+//
+// Empty strings are considered missing.
+func ValueFromContext(key interface{}) ContextExtractor {
+	return func(ctx context.Context) (interface{}, bool) {
+		value := ctx.Value(key)
+		if text, ok := value.(string); ok && len(text) == 0 {
+			return nil, false
+		}
+		return value, value != nil
+	}
+}
+
+// WithContext copies the request-scoped values of the given context into the Attributes of the given error.
+//
+// The values are fetched with the registered ContextExtractor funcs.
+//
+// The Correlation ID, if it is a string, goes to the CorrelationID of the error instead.
+//
+// If the context carries a Config, the error is scoped with it, see WithConfig.
+//
+// If err is nil, WithContext returns nil.
+//
+// If err is an Error or a *Error without a stack trace, the stack trace is recorded.
+// If err is not an Error, it is wrapped with a stack trace first.
+func WithContext(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	final, ok := asError(err)
+	if !ok {
+		if final, ok = WithStack(err).(Error); !ok {
+			return err
+		}
+	} else if len(final.Stack) == 0 {
+		final = final.derive()
+		final.initializeStack()
+		final.enrich()
+	}
+	contextExtractorsLock.RLock()
+	defer contextExtractorsLock.RUnlock()
+	attributes := make(map[string]interface{}, len(final.Attributes)+len(contextExtractors))
+	for key, value := range final.Attributes {
+		attributes[key] = value
+	}
+	for key, extractor := range contextExtractors {
+		if value, found := extractor(ctx); found {
+			attributes[key] = value
+		}
+	}
+	if id, ok := attributes[string(CorrelationIDContextKey)].(string); ok {
+		final.CorrelationID = id
+		delete(attributes, string(CorrelationIDContextKey))
+	}
+	if len(attributes) > 0 {
+		final.Attributes = attributes
+	}
+	if config, ok := ctx.Value(configContextKey{}).(*Config); ok && config != nil {
+		return config.Apply(final)
+	}
+	return final
+}
+
+// WrapWithContext copies the request-scoped values of the given context into the given error, like WithContext,
+// and records the deadline of the context, if any.
+//
+// When the context has a deadline, the following attributes are added:
+//
+//	"deadline"           the deadline of the context (time.Time)
+//	"deadline_remaining" the time left before the deadline when WrapWithContext was called, negative if it passed (time.Duration)
+//	"context_done"       true if the context was already done when WrapWithContext was called
+//
+// These help debugging timeout cascades, where a caller gave too little time to its callees.
+//
+// If err is nil, WrapWithContext returns nil.
+//
+// Example:
+//
+//	if err := client.Call(ctx, request); err != nil {
+//	  return errors.WrapWithContext(ctx, err)
+//	}
+func WrapWithContext(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	final, ok := WithContext(ctx, err).(Error)
+	if !ok {
+		return err
+	}
+	if deadline, found := ctx.Deadline(); found {
+		final = final.WithFields(map[string]interface{}{
+			"deadline":           deadline,
+			"deadline_remaining": time.Until(deadline),
+			"context_done":       ctx.Err() != nil,
+		})
+	}
+	return final
+}
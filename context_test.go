@@ -0,0 +1,146 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanGetCauseFromContext() {
+	requireStack(suite.T())
+	ctx, cancel := context.WithCancelCause(context.Background())
+	suite.Assert().Nil(errors.CauseFromContext(ctx), "context is not done yet")
+
+	cancel(errors.NotFound.With("user", "john"))
+	err := errors.CauseFromContext(ctx)
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().NotEmpty(err.(errors.Error).Stack, "err should have a stack")
+}
+
+func (suite *ErrorsSuite) TestCanGetCauseFromContextWithoutCause() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err := errors.CauseFromContext(ctx)
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, context.DeadlineExceeded)
+	suite.Assert().ErrorIs(err, errors.RuntimeError)
+}
+
+func (suite *ErrorsSuite) TestCanCancelContextWithCause() {
+	ctx, cancel := errors.WithCancelCause(context.Background())
+	child, childCancel := context.WithCancel(ctx)
+	defer childCancel()
+	suite.Assert().Nil(ctx.Err(), "context is not done yet")
+
+	cancel(errors.NotFound.With("user", "john"))
+	<-ctx.Done()
+	suite.Assert().ErrorIs(ctx.Err(), errors.NotFound)
+	suite.Assert().ErrorIs(ctx.Err(), context.Canceled)
+	suite.Assert().Equal("user john Not Found", ctx.Err().Error())
+
+	<-child.Done()
+	suite.Assert().ErrorIs(child.Err(), errors.NotFound)
+	suite.Assert().ErrorIs(child.Err(), context.Canceled)
+	suite.Assert().ErrorIs(errors.CauseFromContext(child), errors.NotFound)
+}
+
+func (suite *ErrorsSuite) TestCanCancelContextWithoutCause() {
+	ctx, cancel := errors.WithCancelCause(context.Background())
+	cancel(nil)
+	<-ctx.Done()
+	suite.Assert().Equal(context.Canceled, ctx.Err())
+}
+
+func (suite *ErrorsSuite) TestCanAddContextValues() {
+	ctx := context.WithValue(context.Background(), errors.CorrelationIDContextKey, "1234")
+	ctx = context.WithValue(ctx, errors.TenantContextKey, "acme")
+	ctx = context.WithValue(ctx, errors.UserContextKey, "")
+
+	err := errors.WithContext(ctx, errors.NotFound.With("user", "john"))
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("1234", details.CorrelationID)
+	suite.Assert().Equal(map[string]interface{}{"tenant": "acme"}, details.Attributes)
+	suite.Assert().Empty(errors.NotFound.Attributes, "NotFound should not have changed")
+
+	suite.Assert().Nil(errors.WithContext(ctx, nil))
+}
+
+func (suite *ErrorsSuite) TestCanAddContextValuesToErrorPointer() {
+	requireStack(suite.T())
+	ctx := context.WithValue(context.Background(), errors.TenantContextKey, "acme")
+
+	err := errors.WithContext(ctx, errors.NotFound.Clone())
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal(errors.NotFound.Code, details.Code)
+	suite.Assert().Equal(errors.NotFound.ID, details.ID)
+	suite.Assert().Equal(errors.NotFound.Text, details.Text)
+	suite.Assert().Equal("acme", details.Attributes["tenant"])
+	suite.Assert().NotEmpty(details.Stack, "err should have a stack")
+}
+
+func (suite *ErrorsSuite) TestCanAddContextValuesToSimpleError() {
+	requireStack(suite.T())
+	ctx := context.WithValue(context.Background(), errors.TraceIDContextKey, "abcd")
+
+	err := errors.WithContext(ctx, fmt.Errorf("simple error"))
+	suite.Require().NotNil(err)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("abcd", details.Attributes["trace_id"])
+	suite.Assert().NotEmpty(details.Stack, "err should have a stack")
+}
+
+func (suite *ErrorsSuite) TestCanRegisterContextExtractor() {
+	type key struct{}
+	errors.RegisterContextExtractor("session", errors.ValueFromContext(key{}))
+	defer errors.UnregisterContextExtractor("session")
+
+	ctx := context.WithValue(context.Background(), key{}, 42)
+	err := errors.WithContext(ctx, errors.NotImplemented)
+	suite.Require().NotNil(err)
+	suite.Assert().Equal(42, err.(errors.Error).Attributes["session"])
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.notimplemented", "code": 501, "text": "Not Implemented", "attributes": {"session": 42}}`, string(payload))
+}
+
+func (suite *ErrorsSuite) TestCanWrapWithContextDeadline() {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.WithValue(context.Background(), errors.TenantContextKey, "acme"), deadline)
+	defer cancel()
+
+	err := errors.WrapWithContext(ctx, errors.NotFound.With("user", "john"))
+	suite.Require().ErrorIs(err, errors.NotFound)
+	details := err.(errors.Error)
+	suite.Assert().Equal("acme", details.Attributes["tenant"])
+	suite.Assert().Equal(deadline, details.Attributes["deadline"])
+	remaining, ok := details.Attributes["deadline_remaining"].(time.Duration)
+	suite.Require().True(ok, "deadline_remaining should be a time.Duration")
+	suite.Assert().InDelta(time.Minute, remaining, float64(time.Second))
+	suite.Assert().Equal(false, details.Attributes["context_done"])
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+	err = errors.WrapWithContext(expired, fmt.Errorf("call failed"))
+	details = err.(errors.Error)
+	suite.Assert().Contains(err.Error(), "call failed")
+	suite.Assert().Equal(true, details.Attributes["context_done"])
+	suite.Assert().Negative(details.Attributes["deadline_remaining"].(time.Duration))
+
+	err = errors.WrapWithContext(context.Background(), errors.NotFound.With("user", "john"))
+	suite.Assert().NotContains(err.(errors.Error).Attributes, "deadline")
+	suite.Assert().Nil(errors.WrapWithContext(ctx, nil))
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"reflect"
+	"slices"
+)
+
+// hasCycle tells if the chain of err loops back on itself
+//
+// path contains the pointer errors that lead to err.
+//
+// As errors are copied when wrapped, a cycle can only happen through pointers, like *Error or *MultiError.
+func hasCycle(err error, path []error) bool {
+	if err == nil {
+		return false
+	}
+	if isPointer(err) {
+		if slices.Contains(path, err) {
+			return true
+		}
+		path = append(path, err)
+	}
+	for _, branch := range branches(err) {
+		if hasCycle(branch, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// breakCycles returns a copy of err where the causes looping back on the chain are replaced by CauseCycle
+//
+// path contains the pointer errors that lead to err.
+//
+// Only Error and MultiError are copied, other errors are returned as is.
+func breakCycles(err error, path []error) error {
+	if err == nil {
+		return nil
+	}
+	if isPointer(err) {
+		if slices.Contains(path, err) {
+			return CauseCycle
+		}
+		path = append(path, err)
+	}
+	switch actual := err.(type) {
+	case Error:
+		actual.Cause = breakCycles(actual.Cause, path)
+		return actual
+	case *Error:
+		if actual == nil {
+			return err
+		}
+		final := *actual
+		final.Cause = breakCycles(actual.Cause, path)
+		return &final
+	case *MultiError:
+		if actual == nil {
+			return err
+		}
+		final := &MultiError{Errors: make([]error, 0, len(actual.Errors))}
+		for _, branch := range actual.Errors {
+			final.Errors = append(final.Errors, breakCycles(branch, path))
+		}
+		return final
+	}
+	return err
+}
+
+// isPointer tells if the given error is a pointer, i.e. it can be part of a cycle
+func isPointer(err error) bool {
+	return reflect.ValueOf(err).Kind() == reflect.Pointer
+}
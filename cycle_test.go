@@ -0,0 +1,46 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestShouldBreakCyclesInError() {
+	inner := errors.ArgumentMissing.Clone()
+	outer := errors.NotFound.Clone()
+	outer.What = "user"
+	outer.Cause = inner
+	inner.What = "name"
+	inner.Cause = outer
+
+	suite.Assert().Equal("user %!s(<nil>) Not Found\nCaused by:\n\tArgument name is missing\nCaused by:\n\tuser %!s(<nil>) Not Found\nCaused by:\n\tCircular cause detected", outer.Error())
+	suite.Assert().Contains(fmt.Sprintf("%+v", outer), "Circular cause detected")
+	suite.Assert().Contains(fmt.Sprintf("%#v", outer), `ID: "error.cause.cycle"`)
+
+	payload, err := json.Marshal(outer)
+	suite.Require().Nil(err)
+	suite.Assert().Contains(string(payload), `"id":"error.cause.cycle"`)
+
+	count := 0
+	suite.Assert().True(errors.Walk(outer, func(err error) bool { count++; return true }))
+	suite.Assert().Equal(2, count)
+}
+
+func (suite *ErrorsSuite) TestShouldBreakCyclesInMultiError() {
+	errs := &errors.MultiError{}
+	err := errors.CreationFailed.Wrap(errs)
+	errs.Append(errors.ArgumentMissing.With("name"), err)
+
+	suite.Assert().Equal("Failed Creating \nCaused by:\n\t2 errors:\nArgument name is missing\nFailed Creating \nCaused by:\n\tCircular cause detected", err.Error())
+	suite.Assert().Len(errors.Chain(err), 3)
+}
+
+func (suite *ErrorsSuite) TestShouldNotDetectCyclesInSharedCauses() {
+	shared := errors.ArgumentMissing.Clone()
+	shared.What = "name"
+	err := errors.CreationFailed.WithCause(shared).WithCause(errors.NotFound.Wrap(shared))
+	suite.Assert().NotContains(err.Error(), "Circular cause detected")
+	suite.Assert().Len(errors.Chain(err), 4)
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+// DefaultMaxCauseDepth is the default maximum number of nested causes rendered by Error(), %+v, and MarshalJSON
+const DefaultMaxCauseDepth = 100
+
+var maxCauseDepth atomic.Int64
+
+func init() {
+	maxCauseDepth.Store(DefaultMaxCauseDepth)
+}
+
+// SetMaxCauseDepth sets the maximum number of nested causes rendered by Error(), %+v, and MarshalJSON
+//
+// The causes beyond that depth are replaced by a CausesTruncated error, like: "... and 12 more causes".
+//
+// A depth of 0 or less means no limit.
+//
+// By default, the maximum depth is DefaultMaxCauseDepth.
+func SetMaxCauseDepth(depth int) {
+	maxCauseDepth.Store(int64(depth))
+}
+
+// WithMaxDepth creates a new Error from a given Error with its own maximum number of nested causes to render.
+//
+// This overrides the depth set with SetMaxCauseDepth. A depth of 0 or less means no limit.
+func (e Error) WithMaxDepth(depth int) Error {
+	final := e.derive()
+	final.maxDepth = max(depth, -1)
+	if final.maxDepth == 0 {
+		final.maxDepth = -1
+	}
+	return final
+}
+
+// depthLimit returns the maximum number of nested causes to render for this Error, 0 means no limit
+func (e Error) depthLimit() int {
+	if e.maxDepth != 0 {
+		return max(e.maxDepth, 0)
+	}
+	if e.config != nil {
+		return max(e.config.MaxCauseDepth, 0)
+	}
+	return int(max(maxCauseDepth.Load(), 0))
+}
+
+// bounded returns a copy of this Error whose causes do not loop and are not nested deeper than its depth limit
+func (e Error) bounded() Error {
+	if e.Cause == nil {
+		return e
+	}
+	if hasCycle(e.Cause, nil) {
+		e.Cause = breakCycles(e.Cause, nil)
+	}
+	if limit := e.depthLimit(); limit > 0 && levels(e.Cause) > limit {
+		e.Cause = truncateCauses(e.Cause, limit)
+	}
+	return e
+}
+
+// levels returns the number of levels in the chain of err, including err itself
+//
+// The members of a MultiError are at the same level as the MultiError.
+//
+// err must not have cycles.
+func levels(err error) int {
+	if err == nil {
+		return 0
+	}
+	deepest := 0
+	for _, branch := range branches(err) {
+		deepest = max(deepest, levels(branch))
+	}
+	if _, ok := err.(*MultiError); ok {
+		return deepest
+	}
+	return deepest + 1
+}
+
+// truncateCauses returns a copy of err that has at most depth levels
+//
+// The levels beyond depth are replaced by a CausesTruncated error.
+//
+// Only Error and MultiError are copied, other errors are returned as is.
+//
+// err must not have cycles.
+func truncateCauses(err error, depth int) error {
+	if err == nil {
+		return nil
+	}
+	if depth <= 0 {
+		truncated := CausesTruncated.derive()
+		truncated.What = strconv.Itoa(len(Chain(err)))
+		return truncated
+	}
+	switch actual := err.(type) {
+	case Error:
+		actual.Cause = truncateCauses(actual.Cause, depth-1)
+		return actual
+	case *Error:
+		if actual == nil {
+			return err
+		}
+		final := *actual
+		final.Cause = truncateCauses(actual.Cause, depth-1)
+		return &final
+	case *MultiError:
+		if actual == nil {
+			return err
+		}
+		final := &MultiError{Errors: make([]error, 0, len(actual.Errors))}
+		for _, member := range actual.Errors {
+			final.Errors = append(final.Errors, truncateCauses(member, depth))
+		}
+		return final
+	}
+	return err
+}
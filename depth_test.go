@@ -0,0 +1,64 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func deepError(depth int) error {
+	var err error = errors.NotFound.With("user", "john")
+	for i := 0; i < depth; i++ {
+		err = errors.CreationFailed.With("user").(errors.Error).WithCause(err)
+	}
+	return err
+}
+
+func (suite *ErrorsSuite) TestShouldLimitCauseDepthInError() {
+	defer errors.SetMaxCauseDepth(errors.DefaultMaxCauseDepth)
+	errors.SetMaxCauseDepth(2)
+	err := deepError(5)
+	suite.Assert().Equal("Failed Creating user\nCaused by:\n\tFailed Creating user\nCaused by:\n\tFailed Creating user\nCaused by:\n\t... and 3 more causes", err.Error())
+	suite.Assert().True(strings.HasPrefix(fmt.Sprintf("%+v", err), err.Error()))
+	suite.Assert().Equal(err.Error(), fmt.Sprintf("%v", err))
+	suite.Assert().True(errors.Is(err, errors.NotFound), "The chain itself should not be truncated")
+}
+
+func (suite *ErrorsSuite) TestShouldLimitCauseDepthInJSON() {
+	defer errors.SetMaxCauseDepth(errors.DefaultMaxCauseDepth)
+	errors.SetMaxCauseDepth(1)
+	payload, err := json.Marshal(deepError(3))
+	suite.Require().Nil(err)
+
+	var decoded errors.Error
+	suite.Require().Nil(json.Unmarshal(payload, &decoded))
+	suite.Assert().Equal("error.creation.failed", decoded.ID)
+	cause, ok := decoded.Cause.(errors.Error)
+	suite.Require().True(ok, "Cause should be an errors.Error")
+	suite.Assert().Equal("error.creation.failed", cause.ID)
+	cause, ok = cause.Cause.(errors.Error)
+	suite.Require().True(ok, "Cause should be an errors.Error")
+	suite.Assert().Equal("error.cause.truncated", cause.ID)
+	suite.Assert().Equal("2", cause.What)
+	suite.Assert().Nil(cause.Cause)
+}
+
+func (suite *ErrorsSuite) TestShouldLimitCauseDepthInMultiError() {
+	defer errors.SetMaxCauseDepth(errors.DefaultMaxCauseDepth)
+	errors.SetMaxCauseDepth(1)
+	err := errors.CreationFailed.WithCause(deepError(1)).WithCause(errors.ArgumentMissing.With("name"))
+	suite.Assert().Equal("Failed Creating \nCaused by:\n\t2 errors:\nFailed Creating user\nCaused by:\n\t... and 1 more causes\nArgument name is missing", err.Error())
+}
+
+func (suite *ErrorsSuite) TestCanOverrideCauseDepthPerError() {
+	err := deepError(3).(errors.Error)
+	suite.Assert().Equal("Failed Creating user\nCaused by:\n\tFailed Creating user\nCaused by:\n\t... and 2 more causes", err.WithMaxDepth(1).Error())
+	suite.Assert().Contains(err.WithMaxDepth(0).Error(), "user john Not Found")
+	suite.Assert().NotContains(err.Error(), "more causes")
+
+	defer errors.SetMaxCauseDepth(errors.DefaultMaxCauseDepth)
+	errors.SetMaxCauseDepth(1)
+	suite.Assert().Contains(err.WithMaxDepth(-1).Error(), "user john Not Found")
+}
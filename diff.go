@@ -0,0 +1,134 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff returns the differences between the expected and the actual errors, one per line, typically for test failures
+//
+// The fields of Error are compared one by one, as well as their causes and the members of MultiError.
+// The Stack is not compared.
+//
+// Each line shows where the difference is, like:
+//
+//	cause.What: expected "user", actual "group"
+//
+// If the errors are the same, Diff returns an empty string.
+func Diff(expected, actual error) string {
+	var differences []string
+
+	diffErrors(&differences, "", expected, actual, nil)
+	return strings.Join(differences, "\n")
+}
+
+// diffErrors adds the differences between expected and actual, path is where they are in the chain
+//
+// visited contains the pointer errors that lead to expected, to stop at cycles.
+func diffErrors(differences *[]string, path string, expected, actual error, visited []error) {
+	if expected == nil || actual == nil {
+		if expected != actual {
+			addDifference(differences, path+"error", errorText(expected), errorText(actual))
+		}
+		return
+	}
+	if isPointer(expected) {
+		for _, ancestor := range visited {
+			if ancestor == expected {
+				return
+			}
+		}
+		visited = append(visited, expected)
+	}
+	expectedMulti, expectedIsMulti := expected.(*MultiError)
+	actualMulti, actualIsMulti := actual.(*MultiError)
+	if expectedIsMulti || actualIsMulti {
+		if !expectedIsMulti || !actualIsMulti {
+			addDifference(differences, path+"error", expected.Error(), actual.Error())
+			return
+		}
+		expectedMembers, actualMembers := branches(expectedMulti), branches(actualMulti)
+		if len(expectedMembers) != len(actualMembers) {
+			addDifference(differences, path+"errors", len(expectedMembers), len(actualMembers))
+		}
+		for index := 0; index < len(expectedMembers) && index < len(actualMembers); index++ {
+			diffErrors(differences, fmt.Sprintf("%serrors[%d].", path, index), expectedMembers[index], actualMembers[index], visited)
+		}
+		return
+	}
+	expectedError, expectedIsError := asError(expected)
+	actualError, actualIsError := asError(actual)
+	if !expectedIsError || !actualIsError {
+		if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+			addDifference(differences, path+"type", fmt.Sprintf("%T", expected), fmt.Sprintf("%T", actual))
+		}
+		if expected.Error() != actual.Error() {
+			addDifference(differences, path+"error", expected.Error(), actual.Error())
+		}
+		return
+	}
+	fields := []struct {
+		name             string
+		expected, actual interface{}
+	}{
+		{"Code", expectedError.Code, actualError.Code},
+		{"ID", expectedError.ID, actualError.ID},
+		{"Text", expectedError.Text, actualError.Text},
+		{"What", expectedError.What, actualError.What},
+		{"Value", expectedError.Value, actualError.Value},
+		{"Range", expectedError.Range, actualError.Range},
+		{"Pointer", expectedError.Pointer, actualError.Pointer},
+		{"URL", expectedError.URL, actualError.URL},
+		{"Severity", expectedError.Severity, actualError.Severity},
+		{"Retryable", expectedError.Retryable, actualError.Retryable},
+		{"Hint", expectedError.Hint, actualError.Hint},
+		{"DocURL", expectedError.DocURL, actualError.DocURL},
+		{"CorrelationID", expectedError.CorrelationID, actualError.CorrelationID},
+		{"Operation", expectedError.Operation, actualError.Operation},
+		{"Component", expectedError.Component, actualError.Component},
+		{"RateLimit", expectedError.RateLimit, actualError.RateLimit},
+		{"Extensions", expectedError.Extensions, actualError.Extensions},
+	}
+	for _, field := range fields {
+		if !reflect.DeepEqual(field.expected, field.actual) {
+			addDifference(differences, path+field.name, field.expected, field.actual)
+		}
+	}
+	keys := make([]string, 0, len(expectedError.Attributes)+len(actualError.Attributes))
+	for key := range expectedError.Attributes {
+		keys = append(keys, key)
+	}
+	for key := range actualError.Attributes {
+		if _, found := expectedError.Attributes[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		expectedValue, actualValue := expectedError.Attributes[key], actualError.Attributes[key]
+		if !reflect.DeepEqual(expectedValue, actualValue) {
+			addDifference(differences, fmt.Sprintf("%sAttributes[%q]", path, key), expectedValue, actualValue)
+		}
+	}
+	if expectedError.Origin != nil || actualError.Origin != nil {
+		diffErrors(differences, path+"origin.", expectedError.Origin, actualError.Origin, visited)
+	}
+	if expectedError.Cause != nil || actualError.Cause != nil {
+		diffErrors(differences, path+"cause.", expectedError.Cause, actualError.Cause, visited)
+	}
+}
+
+// addDifference adds a difference at the given path
+func addDifference(differences *[]string, path string, expected, actual interface{}) {
+	*differences = append(*differences, fmt.Sprintf("%s: expected %#v, actual %#v", path, expected, actual))
+}
+
+// errorText returns the text of the given error, or nil
+func errorText(err error) interface{} {
+	if err == nil {
+		return nil
+	}
+	return err.Error()
+}
@@ -0,0 +1,40 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanDiffErrors() {
+	expected := errors.CreationFailed.With("user").(errors.Error).WithField("tenant", "acme").WithCause(errors.NotFound.With("user", "john"))
+	actual := errors.CreationFailed.With("user").(errors.Error).WithField("tenant", "other").WithField("extra", 1).WithCause(errors.NotFound.With("group", "john"))
+	suite.Assert().Equal(`Attributes["extra"]: expected <nil>, actual 1
+Attributes["tenant"]: expected "acme", actual "other"
+cause.What: expected "user", actual "group"`, errors.Diff(expected, actual))
+}
+
+func (suite *ErrorsSuite) TestShouldNotDiffSameErrors() {
+	expected := errors.CreationFailed.Wrap(errors.NotFound.With("user", "john"))
+	actual := errors.CreationFailed.Wrap(errors.NotFound.With("user", "john"))
+	suite.Assert().Empty(errors.Diff(expected, actual), "Stacks should not be compared")
+	suite.Assert().Empty(errors.Diff(nil, nil))
+}
+
+func (suite *ErrorsSuite) TestCanDiffMultipleCauses() {
+	expected := errors.CreationFailed.WithCause(errors.ArgumentMissing.With("name")).WithCause(errors.NotFound.With("user", "john"))
+	actual := errors.CreationFailed.WithCause(errors.ArgumentMissing.With("name")).WithCause(errors.Timeout.With("user"))
+	suite.Assert().Equal(`cause.errors[1].Code: expected 404, actual 408
+cause.errors[1].ID: expected "error.notfound", actual "error.timeout"
+cause.errors[1].Text: expected "%s %s Not Found", actual "%s Timeout"
+cause.errors[1].Value: expected "john", actual <nil>`, errors.Diff(expected, actual))
+
+	actual = errors.CreationFailed.WithCause(errors.ArgumentMissing.With("name"))
+	suite.Assert().Equal(`cause.error: expected "2 errors:\nArgument name is missing\nuser john Not Found", actual "Argument name is missing"`, errors.Diff(expected, actual))
+}
+
+func (suite *ErrorsSuite) TestCanDiffOtherErrors() {
+	suite.Assert().Equal(`type: expected "*errors.errorString", actual "errors.Error"
+error: expected "boom", actual "Not Implemented"`, errors.Diff(fmt.Errorf("boom"), errors.NotImplemented))
+	suite.Assert().Equal(`error: expected "boom", actual <nil>`, errors.Diff(fmt.Errorf("boom"), nil))
+}
@@ -84,10 +84,10 @@ To return an HTTP Status as an error, you could do this:
 
 You can also add more than one _cause_ to an `errors.Error`, turning it into a _multi-error_ container:
 
-	err := errors.Error{}
-	err.WithCause(errors.ArgumentInvalid.With("key", "value"))
-	err.WithCause(errors.ArgumentMissing.With("key"))
-	err.WithCause(fmt.Errorf("some simple string error"))
+	err := errors.RuntimeError.
+		WithCause(errors.ArgumentInvalid.With("key", "value")).
+		WithCause(errors.ArgumentMissing.With("key")).
+		WithCause(fmt.Errorf("some simple string error"))
 
 Finally, errors.Error supports JSON serialization.
 
@@ -0,0 +1,95 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ToDOT returns a Graphviz DOT graph of err and every error in its chain
+//
+// Each Error is a node labeled with its Code, ID, and message,
+// each cause and MultiError member is an edge from its parent.
+//
+// Errors given as pointers, like *Error or *MultiError, are shown once even if they appear several times in the chain.
+// Thus a chain that loops back on itself shows as a cycle in the graph.
+//
+// Example:
+//
+//	os.WriteFile("error.dot", []byte(errors.ToDOT(err)), 0644)
+//	// then: dot -Tsvg error.dot > error.svg
+//
+// If err is nil, ToDOT returns an empty graph.
+func ToDOT(err error) string {
+	var sb strings.Builder
+
+	_, _ = sb.WriteString("digraph errors {\n\tnode [shape=box];\n")
+	if err != nil {
+		graph := dotGraph{sb: &sb, nodes: map[error]string{}}
+		graph.node(err)
+	}
+	_, _ = sb.WriteString("}\n")
+	return sb.String()
+}
+
+// ToDOT returns a Graphviz DOT graph of this Error and every error in its chain.
+//
+// See ToDOT.
+func (e Error) ToDOT() string {
+	return ToDOT(e)
+}
+
+// dotGraph writes the nodes and edges of a DOT graph
+type dotGraph struct {
+	sb    *strings.Builder
+	nodes map[error]string
+	count int
+}
+
+// node writes the node of err and its branches, if not written yet, and returns its name
+func (graph *dotGraph) node(err error) string {
+	if isPointer(err) {
+		if name, found := graph.nodes[err]; found {
+			return name
+		}
+	}
+	name := "n" + strconv.Itoa(graph.count)
+	graph.count++
+	if isPointer(err) {
+		graph.nodes[err] = name
+	}
+	shape := ""
+	if _, ok := err.(*MultiError); ok {
+		shape = ", shape=ellipse"
+	}
+	_, _ = fmt.Fprintf(graph.sb, "\t%s [label=%s%s];\n", name, dotQuote(dotLabel(err)), shape)
+	for _, branch := range branches(err) {
+		_, _ = fmt.Fprintf(graph.sb, "\t%s -> %s;\n", name, graph.node(branch))
+	}
+	return name
+}
+
+// dotLabel returns the label of the node of err in a DOT graph
+func dotLabel(err error) string {
+	if value, ok := asError(err); ok {
+		var sb strings.Builder
+		if value.Code != 0 {
+			_, _ = sb.WriteString("[")
+			_, _ = sb.WriteString(strconv.Itoa(value.Code))
+			_, _ = sb.WriteString("] ")
+		}
+		if len(value.ID) > 0 {
+			_, _ = sb.WriteString(value.ID)
+			_, _ = sb.WriteString("\n")
+		}
+		_, _ = sb.WriteString(value.message())
+		return sb.String()
+	}
+	return treeLabel(err)
+}
+
+// dotQuote returns the given text as a DOT quoted string
+func dotQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", "")
+	return `"` + replacer.Replace(text) + `"`
+}
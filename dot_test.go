@@ -0,0 +1,48 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertToDOT() {
+	err := errors.CreationFailed.With(`"user"`).(errors.Error).
+		WithCause(errors.ArgumentMissing.With("name")).
+		WithCause(fmt.Errorf("lookup: %w", errors.NotFound.With("group", "admins")))
+	expected := `digraph errors {
+	node [shape=box];
+	n0 [label="[500] error.creation.failed\nFailed Creating \"user\""];
+	n1 [label="2 errors", shape=ellipse];
+	n2 [label="[400] error.argument.missing\nArgument name is missing"];
+	n1 -> n2;
+	n3 [label="lookup: group admins Not Found"];
+	n4 [label="[404] error.notfound\ngroup admins Not Found"];
+	n3 -> n4;
+	n1 -> n3;
+	n0 -> n1;
+}
+`
+	suite.Assert().Equal(expected, errors.ToDOT(err))
+	suite.Assert().Equal(expected, err.ToDOT())
+}
+
+func (suite *ErrorsSuite) TestCanConvertToDOTWithCycle() {
+	inner := errors.ArgumentMissing.Clone()
+	outer := errors.NotFound.Clone()
+	outer.Cause = inner
+	inner.Cause = outer
+	expected := `digraph errors {
+	node [shape=box];
+	n0 [label="[404] error.notfound\n %!s(<nil>) Not Found"];
+	n1 [label="[400] error.argument.missing\nArgument  is missing"];
+	n1 -> n0;
+	n0 -> n1;
+}
+`
+	suite.Assert().Equal(expected, errors.ToDOT(outer))
+}
+
+func (suite *ErrorsSuite) TestShouldConvertNilErrorToEmptyDOT() {
+	suite.Assert().Equal("digraph errors {\n\tnode [shape=box];\n}\n", errors.ToDOT(nil))
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// Envelope is the error envelope used by many public APIs, like Stripe's:
+//
+//	{"error": {"type": "invalid_request_error", "code": "error.argument.missing", "message": "Argument email is missing", "param": "email"}}
+type Envelope struct {
+	Error EnvelopeError `json:"error"`
+}
+
+// EnvelopeError is the content of an Envelope
+type EnvelopeError struct {
+	// Type is "api_error" for server errors (5xx), "invalid_request_error" otherwise
+	Type string `json:"type"`
+	// Code is the ID of the error, like: "error.argument.missing"
+	Code string `json:"code,omitempty"`
+	// Message is the message of the error, without its causes
+	Message string `json:"message"`
+	// Param is the What of the error, like the parameter that is missing or invalid
+	Param string `json:"param,omitempty"`
+	// DocURL is the URL of the documentation of the error
+	DocURL string `json:"doc_url,omitempty"`
+}
+
+const (
+	// EnvelopeAPIError is the Type of the Envelope of server errors
+	EnvelopeAPIError = "api_error"
+	// EnvelopeInvalidRequestError is the Type of the Envelope of the other errors
+	EnvelopeInvalidRequestError = "invalid_request_error"
+)
+
+// ToEnvelope converts err into an Envelope
+//
+// The first Error of err's chain gives the code (its ID), the message (without the causes), the param (its What),
+// and the doc_url. If there is no Error in the chain, the envelope is an "api_error" with the message of err.
+//
+// If err is nil, ToEnvelope returns an empty Envelope.
+func ToEnvelope(err error) Envelope {
+	if err == nil {
+		return Envelope{}
+	}
+	var details Error
+	if As(err, &details) {
+		return details.ToEnvelope()
+	}
+	return Envelope{Error: EnvelopeError{Type: EnvelopeAPIError, Code: "error.runtime", Message: err.Error()}}
+}
+
+// ToEnvelope converts this Error into an Envelope, see ToEnvelope
+func (e Error) ToEnvelope() Envelope {
+	envelope := EnvelopeError{
+		Type:    EnvelopeInvalidRequestError,
+		Code:    e.ID,
+		Message: e.message(),
+		Param:   e.What,
+		DocURL:  e.DocURL,
+	}
+	if e.Code == 0 || e.Code >= http.StatusInternalServerError {
+		envelope.Type = EnvelopeAPIError
+	}
+	return Envelope{Error: envelope}
+}
@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertToEnvelope() {
+	err := errors.ArgumentMissing.With("email").(errors.Error).WithCause(fmt.Errorf("form is empty"))
+	payload, jerr := json.Marshal(errors.ToEnvelope(err))
+	suite.Require().NoError(jerr)
+	expected := `{"error": {"type": "invalid_request_error", "code": "error.argument.missing", "message": "Argument email is missing", "param": "email"}}`
+	suite.Assert().JSONEq(expected, string(payload))
+}
+
+func (suite *ErrorsSuite) TestCanConvertServerErrorToEnvelope() {
+	sentinel := errors.NewSentinel(500, "error.test.envelope", "Storage %s failed", errors.DocURL("https://docs.acme.com/errors/storage"))
+	err := fmt.Errorf("saving: %w", sentinel.With("bucket"))
+	envelope := errors.ToEnvelope(err)
+	suite.Assert().Equal(errors.EnvelopeAPIError, envelope.Error.Type)
+	suite.Assert().Equal("error.test.envelope", envelope.Error.Code)
+	suite.Assert().Equal("Storage bucket failed", envelope.Error.Message)
+	suite.Assert().Equal("bucket", envelope.Error.Param)
+	suite.Assert().Equal("https://docs.acme.com/errors/storage", envelope.Error.DocURL)
+
+	envelope = errors.ToEnvelope(fmt.Errorf("boom"))
+	suite.Assert().Equal(errors.EnvelopeError{Type: errors.EnvelopeAPIError, Code: "error.runtime", Message: "boom"}, envelope.Error)
+	suite.Assert().Equal(errors.Envelope{}, errors.ToEnvelope(nil))
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"syscall"
+)
+
+// FromErrno converts an error carrying a syscall.Errno into an Error
+//
+// The errno values are mapped to the following sentinels:
+//
+//	ECONNREFUSED -> ConnectionRefused (retryable)
+//	ECONNRESET   -> ConnectionReset (retryable)
+//	ETIMEDOUT    -> Timeout (retryable)
+//	EPIPE        -> BrokenPipe (retryable)
+//	ENOSPC       -> DiskFull
+//
+// If err is nil, FromErrno returns nil. Errors and *Error are returned as is.
+func FromErrno(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	var errno syscall.Errno
+	if !As(err, &errno) {
+		return WithStack(err)
+	}
+	final, ok := errnoSentinel(errno)
+	if !ok {
+		return WithStack(err)
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// errnoSentinel gives the sentinel of the given errno, see FromErrno
+func errnoSentinel(errno syscall.Errno) (Error, bool) {
+	switch errno {
+	case syscall.ECONNREFUSED:
+		return ConnectionRefused.derive(), true
+	case syscall.ECONNRESET:
+		return ConnectionReset.derive(), true
+	case syscall.ETIMEDOUT:
+		final := Timeout.derive()
+		final.What = "Connection"
+		final.Retryable = true
+		return final, true
+	case syscall.EPIPE:
+		return BrokenPipe.derive(), true
+	case syscall.ENOSPC:
+		return DiskFull.derive(), true
+	}
+	return Error{}, false
+}
@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertErrnos() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromErrno(nil))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromErrno(pointer), "a *Error should be returned as is")
+
+	err := errors.FromErrno(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ConnectionRefused)
+	suite.Assert().ErrorIs(err, syscall.ECONNREFUSED)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().True(details.Retryable)
+	suite.Assert().NotEmpty(details.Stack)
+
+	err = errors.FromErrno(fmt.Errorf("read: %w", syscall.ECONNRESET))
+	suite.Assert().ErrorIs(err, errors.ConnectionReset)
+	suite.Assert().True(err.(errors.Error).Retryable)
+
+	err = errors.FromErrno(syscall.ETIMEDOUT)
+	suite.Assert().ErrorIs(err, errors.Timeout)
+	suite.Assert().True(err.(errors.Error).Retryable)
+
+	err = errors.FromErrno(syscall.EPIPE)
+	suite.Assert().ErrorIs(err, errors.BrokenPipe)
+
+	err = errors.FromErrno(syscall.ENOSPC)
+	suite.Assert().ErrorIs(err, errors.DiskFull)
+	suite.Assert().False(err.(errors.Error).Retryable)
+	suite.Assert().Equal(507, err.(errors.Error).Code)
+}
+
+func (suite *ErrorsSuite) TestShouldNotConvertUnknownErrnos() {
+	err := errors.FromErrno(syscall.EINVAL)
+	suite.Assert().ErrorIs(err, syscall.EINVAL)
+	suite.Assert().NotErrorIs(err, errors.ConnectionRefused)
+
+	err = errors.FromErrno(fmt.Errorf("not an errno"))
+	suite.Assert().Equal("error.runtime", err.(errors.Error).ID)
+}
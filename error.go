@@ -1,12 +1,16 @@
 package errors
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // Error describes an augmented implementation of Go's error interface
@@ -22,17 +26,67 @@ type Error struct {
 	// Value contains the value that was wrong for errors that need it, like ArgumentInvalidError
 	// TODO: use structpb
 	Value interface{} `json:"value,omitempty"`
+	// Range contains the allowed range of the Value, if any, see WithRange
+	Range *Range `json:"range,omitempty"`
+	// Pointer contains the JSON Pointer (RFC 6901) of the property this Error is about, like: "/spec/replicas"
+	Pointer string `json:"pointer,omitempty"`
+	// URL contains the URL this Error is about, its password is redacted, see WithURL
+	URL string `json:"url,omitempty"`
+	// Severity tells how severe this Error is, an empty Severity means SeverityError
+	Severity Severity `json:"severity,omitempty"`
+	// Retryable tells if the operation that failed can be attempted again
+	Retryable bool `json:"retryable,omitempty"`
+	// Hint contains some guidance to resolve this Error, like: "set the GOOGLE_APPLICATION_CREDENTIALS environment variable"
+	Hint string `json:"hint,omitempty"`
+	// DocURL contains the URL of the documentation of this Error, like a runbook page
+	DocURL string `json:"doc_url,omitempty"`
+	// CorrelationID contains the identifier of the request or transaction that failed, if any
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Operation contains the operation that failed, like: "db.query"
+	Operation string `json:"operation,omitempty"`
+	// Component contains the component where this Error happened, like: "billing"
+	Component string `json:"component,omitempty"`
+	// Service contains the service and the host where this Error was created, see SetServiceInfo
+	Service *ServiceInfo `json:"origin,omitempty"`
+	// Build contains the build that produced this Error, see WithBuildInfo and EnableBuildInfo
+	Build *BuildInfo `json:"build,omitempty"`
+	// RateLimit contains the limit that was exceeded, if any, see WithLimit
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
+	// Attributes contains arbitrary structured metadata about this Error, like the request identity
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Extensions contains the JSON properties this package does not know, they are marshaled back as they are
+	//
+	// This allows to proxy errors from newer versions of this package or from other services.
+	Extensions map[string]json.RawMessage `json:"-"`
 	// Origin contains the real error from another package, if any
 	Origin error `json:"-"`
 	// Cause contains the error that caused this error
 	Cause error `json:"-"`
 	// stack contains the StackTrace when this Error is instanciated
 	Stack StackTrace `json:"-"`
+	// matchCode tells if this sentinel matches any Error with the same Code, see MatchCode
+	matchCode bool
+	// parents contains the IDs of the sentinels this sentinel extends, see Extends
+	parents []string
+	// maxDepth is the maximum number of nested causes to render, see WithMaxDepth
+	maxDepth int
+	// maxLength is the maximum number of characters of the message to render, see WithMaxLength
+	maxLength int
+	// stackSampling is the stack sampling rate of sentinels, see SampleStacks
+	stackSampling int
+	// config is the Config this Error was scoped with, if any, see Config.Apply
+	config *Config
+	// template is the preparsed Text of sentinels, it is ignored if Text was changed
+	template *textTemplate
+	// seal is the state of this sentinel when it was created, it is nil for the errors derived from sentinels, see FreezeSentinels
+	seal *sentinelSeal
 }
 
 // Clone creates an exact copy of this Error
+//
+// The copy shares its Stack, Attributes, Value, and causes with this Error, see CloneDeep.
 func (e Error) Clone() *Error {
-	final := e
+	final := e.derive()
 	return &final
 }
 
@@ -55,13 +109,22 @@ func (e Error) Is(target error) bool {
 		if len(actual.ID) == 0 {
 			return true // no ID means any error is a match
 		}
-		return e.ID == actual.ID
+		return e.ID == actual.ID || e.extends(actual.ID) || (actual.matchCode && e.Code == actual.Code)
 	}
 	if actual, ok := target.(*Error); ok && actual != nil {
 		if len(actual.ID) == 0 {
 			return true // no ID means any error is a match
 		}
-		return e.ID == actual.ID
+		return e.ID == actual.ID || e.extends(actual.ID) || (actual.matchCode && e.Code == actual.Code)
+	}
+	if category, ok := target.(categoryTarget); ok && e.InCategory(string(category)) {
+		return true
+	}
+	if pattern, ok := target.(patternTarget); ok && e.MatchID(string(pattern)) {
+		return true
+	}
+	if code, ok := target.(codeTarget); ok && e.Code == int(code) {
+		return true
 	}
 	if e.Origin != nil {
 		return Is(e.Origin, target)
@@ -84,7 +147,7 @@ func (e Error) As(target interface{}) bool {
 		if *actual != nil && (*actual).GetID() != e.ID {
 			return false
 		}
-		copy := e
+		copy := e.derive()
 		*actual = &copy
 		return true
 	}
@@ -103,11 +166,35 @@ func (e Error) Wrap(err error) error {
 	if err == nil {
 		return nil
 	}
-	final := e
+	final := e.derive()
 	final.Cause = err
 	if len(final.Stack) == 0 {
-		final.Stack.Initialize()
+		final.initializeStack()
 	}
+	final.enrich()
+	return final
+}
+
+// Wrapf wraps the given error in this Error, with the formatted message as its What.
+//
+// As the message is the What, it is rendered with the Text of this Error, like:
+//
+//	errors.CreationFailed.Wrapf(err, "bucket %s", name) // Failed Creating bucket photos
+//
+// If err is nil, Wrapf returns nil.
+//
+// Wrapf also records the stack trace at the point it was called.
+func (e Error) Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	final := e.derive()
+	final.What = fmt.Sprintf(format, args...)
+	final.Cause = err
+	if len(final.Stack) == 0 {
+		final.initializeStack()
+	}
+	final.enrich()
 	return final
 }
 
@@ -124,8 +211,51 @@ func (e Error) WrapIfNotMe(err error) error {
 	return e.Wrap(err)
 }
 
+// WithCause creates a new Error from a given Error with an additional cause.
+//
+// If the Error already has a cause, the causes are collected in a MultiError.
+//
+// If cause is nil, the Error is returned unchanged.
+func (e Error) WithCause(cause error) Error {
+	final := e.derive()
+	if cause == nil {
+		return final
+	}
+	if final.Cause == nil {
+		final.Cause = cause
+		return final
+	}
+	multi := &MultiError{}
+	if current, ok := final.Cause.(*MultiError); ok && current != nil {
+		multi.Errors = append(multi.Errors, current.Errors...)
+	} else {
+		multi.Errors = append(multi.Errors, final.Cause)
+	}
+	multi.Append(cause)
+	final.Cause = multi
+	return final
+}
+
+// Causes gives the direct causes of this Error, if any.
+//
+// If the Error has several causes, they are returned in the order they were added.
+func (e Error) Causes() []error {
+	if multi, ok := e.Cause.(*MultiError); ok {
+		if multi == nil {
+			return nil
+		}
+		return append([]error{}, multi.Errors...)
+	}
+	if e.Cause == nil {
+		return nil
+	}
+	return []error{e.Cause}
+}
+
 // Unwrap gives the first Cause of this Error, if any.
 //
+// If this Error has several causes, they are given as a *MultiError.
+//
 // implements errors.Unwrap interface (package "errors").
 func (e Error) Unwrap() error {
 	if e.Cause == nil {
@@ -134,35 +264,269 @@ func (e Error) Unwrap() error {
 	return e.Cause
 }
 
+// WithWhat creates a new Error from a given sentinel telling "what" is wrong and eventually their value.
+//
+// Unlike With, WithWhat returns an Error so it can be decorated further without type assertions, like:
+//
+//	err := errors.NotFound.WithWhat("user", id).WithField("tenant", tenant).WithCause(cause)
+//
+// WithWhat also records the stack trace at the point it was called.
+func (e Error) WithWhat(what string, values ...interface{}) Error {
+	final := e.derive()
+	final.What = what
+	if len(values) > 0 {
+		final.Value = values[0]
+	}
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
 // With creates a new Error from a given sentinel telling "what" is wrong and eventually their value.
 //
+// If a minimum and a maximum are given after the value, they become the allowed Range, like:
+//
+//	errors.ArgumentOutOfRange.With("page", 500, 1, 100) // Argument page is out of range (value: 500, allowed: 1..100)
+//
 // With also records the stack trace at the point it was called.
 func (e Error) With(what string, values ...interface{}) error {
-	final := e
+	final := e.derive()
 	final.What = what
 	if len(values) > 0 {
 		final.Value = values[0]
 	}
-	final.Stack.Initialize()
+	if len(values) > 2 {
+		final.Range = &Range{Min: values[1], Max: values[2]}
+	}
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// WithMessagef creates a new error from a given Error with the formatted message as its What, and records its stack.
+//
+// As the message is the What, it is rendered with the Text of this Error, like:
+//
+//	errors.ArgumentMissing.WithMessagef("header %s", name) // Argument header X-Tenant is missing
+func (e Error) WithMessagef(format string, args ...interface{}) error {
+	final := e.derive()
+	final.What = fmt.Sprintf(format, args...)
+	final.initializeStack()
+	final.enrich()
 	return final
 }
 
 // WithStack creates a new error from a given Error and records its stack.
 func (e Error) WithStack() error {
-	final := e
-	final.Stack.Initialize()
+	final := e.derive()
+	final.initializeStack()
+	final.enrich()
 	return final
 }
 
 // WithoutStack creates a new error from a given Error and records its stack.
 func (e Error) WithoutStack() error {
-	final := e
+	final := e.derive()
 	final.Stack = StackTrace{}
 	return final
 }
 
+// StackTrace returns the StackTrace of this Error
+//
+// Like github.com/pkg/errors, each frame is the program counter of a return address,
+// so reporting tools that look for a StackTracer, like Sentry, pick up the StackTrace of this Error.
+func (e Error) StackTrace() StackTrace {
+	return e.Stack
+}
+
+// WithoutFrames creates a new Error from a given Error without the stack frames of the functions starting with the given prefixes.
+//
+// See StackTrace.Filter and SetFrameFilter.
+func (e Error) WithoutFrames(prefixes ...string) Error {
+	final := e.derive()
+	final.Stack = e.Stack.Filter(prefixes...)
+	return final
+}
+
+// WithCode creates a new Error from a given Error with the given Code.
+//
+// Example, to report the failure of an upstream service at the edge:
+//
+//	return errors.WithStack(upstreamErr.WithCode(http.StatusBadGateway))
+func (e Error) WithCode(code int) Error {
+	final := e.derive()
+	final.Code = code
+	return final
+}
+
+// WithID creates a new Error from a given Error with the given ID.
+//
+// As errors.Is compares IDs, the new Error does not match the given Error anymore, but it matches errors with the new ID.
+//
+// Example:
+//
+//	return errors.NotFound.WithID("error.notfound.user").With("user", id)
+func (e Error) WithID(id string) Error {
+	final := e.derive()
+	final.ID = id
+	return final
+}
+
+// WithText creates a new Error from a given Error with the given Text.
+//
+// The Text is a format, like the Text of the sentinels, its verbs are replaced by What and Value.
+func (e Error) WithText(text string) Error {
+	final := e.derive()
+	final.Text = text
+	return final
+}
+
+// WithSeverity creates a new Error from a given Error with the given Severity.
+func (e Error) WithSeverity(severity Severity) Error {
+	final := e.derive()
+	final.Severity = severity
+	return final
+}
+
+// WithRetryable creates a new Error from a given Error telling if the operation that failed can be attempted again.
+func (e Error) WithRetryable(retryable bool) Error {
+	final := e.derive()
+	final.Retryable = retryable
+	return final
+}
+
+// WithHint creates a new Error from a given Error with some guidance to resolve it.
+func (e Error) WithHint(hint string) Error {
+	final := e.derive()
+	final.Hint = hint
+	return final
+}
+
+// WithDocURL creates a new Error from a given Error with the URL of its documentation.
+func (e Error) WithDocURL(url string) Error {
+	final := e.derive()
+	final.DocURL = url
+	return final
+}
+
+// WithCorrelationID creates a new Error from a given Error with the given Correlation ID.
+func (e Error) WithCorrelationID(id string) Error {
+	final := e.derive()
+	final.CorrelationID = id
+	return final
+}
+
+// WithField creates a new Error from a given Error with the given attribute.
+//
+// The Attributes of the given Error are not modified.
+func (e Error) WithField(key string, value interface{}) Error {
+	return e.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields creates a new Error from a given Error with the given attributes.
+//
+// The Attributes of the given Error are not modified.
+func (e Error) WithFields(fields map[string]interface{}) Error {
+	final := e.derive()
+	final.Attributes = make(map[string]interface{}, len(e.Attributes)+len(fields))
+	for key, value := range e.Attributes {
+		final.Attributes[key] = value
+	}
+	for key, value := range fields {
+		final.Attributes[key] = value
+	}
+	return final
+}
+
+// WithPointer creates a new Error from a given Error with the JSON Pointer (RFC 6901) of the property it is about.
+//
+// Example:
+//
+//	err := errors.JSONPropertyMissing.WithWhat("replicas").WithPointer(errors.JSONPointer("spec", "replicas"))
+func (e Error) WithPointer(pointer string) Error {
+	final := e.derive()
+	final.Pointer = pointer
+	return final
+}
+
+// WithOperation creates a new Error from a given Error with the operation that failed.
+func (e Error) WithOperation(operation string) Error {
+	final := e.derive()
+	final.Operation = operation
+	return final
+}
+
+// WithComponent creates a new Error from a given Error with the component where it happened.
+func (e Error) WithComponent(component string) Error {
+	final := e.derive()
+	final.Component = component
+	return final
+}
+
+// Fields returns the structured fields of this Error, typically for logging.
+//
+// The Attributes are added as well, but they cannot override the other fields.
+func (e Error) Fields() map[string]interface{} {
+	fields := make(map[string]interface{}, len(e.Attributes)+8)
+	for key, value := range e.Attributes {
+		fields[key] = value
+	}
+	fields["id"] = e.ID
+	fields["code"] = e.Code
+	fields["message"] = e.message()
+	if len(e.What) > 0 {
+		fields["what"] = e.What
+	}
+	if e.Value != nil {
+		fields["value"] = e.Value
+	}
+	if len(e.Pointer) > 0 {
+		fields["pointer"] = e.Pointer
+	}
+	if len(e.URL) > 0 {
+		fields["url"] = e.URL
+	}
+	if len(e.Severity) > 0 {
+		fields["severity"] = e.Severity
+	}
+	if e.Retryable {
+		fields["retryable"] = true
+	}
+	if len(e.Hint) > 0 {
+		fields["hint"] = e.Hint
+	}
+	if len(e.DocURL) > 0 {
+		fields["doc_url"] = e.DocURL
+	}
+	if len(e.CorrelationID) > 0 {
+		fields["correlation_id"] = e.CorrelationID
+	}
+	if len(e.Operation) > 0 {
+		fields["operation"] = e.Operation
+	}
+	if len(e.Component) > 0 {
+		fields["component"] = e.Component
+	}
+	if e.Service != nil {
+		fields["origin"] = e.Service
+	}
+	if e.Build != nil {
+		fields["build"] = e.Build
+	}
+	if e.RateLimit != nil {
+		fields["rate_limit"] = e.RateLimit
+	}
+	if e.Range != nil {
+		fields["range"] = e.Range
+	}
+	return fields
+}
+
 // Error returns the string version of this error.
 //
+// The messages of sentinels without causes are rendered without allocating memory,
+// or with a single allocation when they use %s or %v with a string What and Value.
+//
 // implements error interface.
 func (e Error) Error() string {
 	// At some point this should be a pointer receiver
@@ -170,37 +534,274 @@ func (e Error) Error() string {
 	// But when it is, it breaks the errors.As() as it cannot find sentinel errors anymore:
 	// Line wrap.go:92 is always true so line wrap.go:96 is never reached and Error.As never called.
 	// https://cs.opensource.google/go/go/+/refs/tags/go1.19.3:src/errors/wrap.go;drc=2580d0e08d5e9f979b943758d3c49877fb2324cb;l=92
-	if e.Origin != nil {
-		return e.Origin.Error()
+	if e.Cause == nil && (len(e.CorrelationID) == 0 || !showCorrelationID.Load()) {
+		return e.message()
 	}
+	separator, _ := e.causeSeparators()
+	return e.text(separator, false)
+}
+
+// text returns the text of this Error and of its causes, separated by the given separator
+//
+// In compact mode, the members of MultiError causes are written on a single line.
+func (e Error) text(separator string, compact bool) string {
 	var sb strings.Builder
 
-	switch strings.Count(e.Text, "%") - strings.Count(e.Text, "%%") {
+	e.bounded().writeText(&sb, separator, compact)
+	return sb.String()
+}
+
+// writeText writes the text of this Error and of its causes in the given builder
+func (e Error) writeText(sb *strings.Builder, separator string, compact bool) {
+	if e.Origin != nil {
+		_, _ = sb.WriteString(e.Origin.Error())
+		return
+	}
+	message := e.message()
+	_, _ = sb.WriteString(message)
+	// Sanitize already wrote the Correlation ID in the message of 5xx errors
+	if len(e.CorrelationID) > 0 && showCorrelationID.Load() && !strings.HasSuffix(message, " (ref: "+e.CorrelationID+")") {
+		_, _ = sb.WriteString(" (ref: ")
+		_, _ = sb.WriteString(e.CorrelationID)
+		_, _ = sb.WriteString(")")
+	}
+	if e.Cause != nil {
+		_, _ = sb.WriteString(separator)
+		writeCauseText(sb, e.Cause, separator, compact)
+	}
+}
+
+// writeCauseText writes the text of the given cause in the given builder
+func writeCauseText(sb *strings.Builder, cause error, separator string, compact bool) {
+	if value, ok := asError(cause); ok {
+		value.writeText(sb, separator, compact)
+		return
+	}
+	multi, ok := cause.(*MultiError)
+	if ok && multi != nil && len(multi.Errors) == 1 {
+		writeCauseText(sb, multi.Errors[0], separator, compact)
+		return
+	}
+	if !ok || multi == nil || len(multi.Errors) == 0 {
+		_, _ = sb.WriteString(cause.Error())
+		return
+	}
+	_, _ = sb.WriteString(strconv.Itoa(len(multi.Errors)))
+	_, _ = sb.WriteString(" errors:")
+	if compact {
+		_, _ = sb.WriteString(" [")
+	}
+	for index, member := range multi.Errors {
+		if !compact {
+			_, _ = sb.WriteString("\n")
+		} else if index > 0 {
+			_, _ = sb.WriteString("; ")
+		}
+		writeCauseText(sb, member, separator, compact)
+	}
+	if compact {
+		_, _ = sb.WriteString("]")
+	}
+}
+
+// DefaultCauseSeparator is the default separator between an Error and its cause
+const DefaultCauseSeparator = "\nCaused by:\n\t"
+
+// DefaultCompactCauseSeparator is the default separator between an Error and its cause when formatted with %-v
+const DefaultCompactCauseSeparator = ": "
+
+var causeSeparator, compactCauseSeparator atomic.Value
+
+func init() {
+	causeSeparator.Store(DefaultCauseSeparator)
+	compactCauseSeparator.Store(DefaultCompactCauseSeparator)
+}
+
+// SetCauseSeparator sets the separator written between an Error and its cause by Error(), %v, and %+v
+//
+// By default, the separator is DefaultCauseSeparator.
+func SetCauseSeparator(separator string) {
+	causeSeparator.Store(separator)
+}
+
+// SetCompactCauseSeparator sets the separator written between an Error and its cause by %-v
+//
+// By default, the separator is DefaultCompactCauseSeparator.
+func SetCompactCauseSeparator(separator string) {
+	compactCauseSeparator.Store(separator)
+}
+
+// message returns the Text of this Error formatted with its What and Value
+func (e Error) message() string {
+	if limit := e.lengthLimit(); limit > 0 {
+		unlimited := e
+		unlimited.maxLength = -1
+		text := unlimited.message()
+		if cut, truncated := truncateText(text, limit); truncated {
+			return cut + TruncatedMarker
+		}
+		return text
+	}
+	if e.RateLimit != nil {
+		limited := e
+		limited.RateLimit = nil
+		return limited.message() + " (" + e.RateLimit.String() + ")"
+	}
+	if e.Range != nil {
+		ranged := e
+		ranged.Range = nil
+		text := ranged.message()
+		if strings.HasSuffix(text, ")") { // like: "Argument page is out of range (value: 500)"
+			return text[:len(text)-1] + ", allowed: " + e.Range.String() + ")"
+		}
+		return text + " (allowed: " + e.Range.String() + ")"
+	}
+	if e.Origin != nil {
+		return e.Origin.Error()
+	}
+	if len(e.What) == 0 && len(e.URL) > 0 && e.verbs() > 0 { // like: "Invalid URL https://acme.com:port"
+		located := e
+		located.What = e.URL
+		return located.message()
+	}
+	if e.template != nil && e.template.text == e.Text {
+		if text, ok := e.template.render(e.What, e.Value); ok {
+			return text
+		}
+	}
+	switch e.verbs() {
 	case 0:
 		if len(e.Text) > 0 {
-			_, _ = sb.WriteString(e.Text)
+			return strings.ReplaceAll(e.Text, "%%", "%")
 		} else if len(e.ID) > 0 {
-			_, _ = sb.WriteString(e.ID)
-		} else {
-			_, _ = sb.WriteString("runtime error")
+			return e.ID
 		}
+		return "runtime error"
 	case 1:
-		_, _ = fmt.Fprintf(&sb, e.Text, e.What)
+		return fmt.Sprintf(e.Text, e.What)
 	default:
-		_, _ = fmt.Fprintf(&sb, e.Text, e.What, e.Value)
+		return fmt.Sprintf(e.Text, e.What, e.Value)
 	}
-	if e.Cause != nil {
-		_, _ = sb.WriteString("\nCaused by:")
-		_, _ = sb.WriteString("\n\t")
-		_, _ = sb.WriteString(e.Cause.Error())
+}
+
+// textTemplate is a preparsed Text
+//
+// If the verbs of the Text are only %s or %v, parts contains the text around them,
+// so the message can be rendered without fmt. If the Text has no verbs, parts contains the Text with its %% unescaped.
+type textTemplate struct {
+	text  string
+	verbs int
+	parts []string
+}
+
+// parseText preparses the given Text
+func parseText(text string) *textTemplate {
+	template := &textTemplate{text: text, verbs: countVerbs(text)}
+	if template.verbs == 0 {
+		template.parts = []string{strings.ReplaceAll(text, "%%", "%")}
+		return template
+	}
+	if template.verbs > 2 {
+		return template
+	}
+	parts := make([]string, 0, template.verbs+1)
+	start := 0
+	for index := strings.IndexByte(text, '%'); index >= 0; index = strings.IndexByte(text[start:], '%') {
+		index += start
+		if index+1 >= len(text) || (text[index+1] != 's' && text[index+1] != 'v') {
+			return template // %%, flags, other verbs are rendered by fmt
+		}
+		parts = append(parts, text[start:index])
+		start = index + 2
+	}
+	template.parts = append(parts, text[start:])
+	return template
+}
+
+// render renders the preparsed Text with the given What and Value
+//
+// render returns false if the text must be rendered by fmt.
+func (template textTemplate) render(what string, value interface{}) (string, bool) {
+	switch {
+	case template.verbs == 0 && len(template.text) > 0:
+		return template.parts[0], true
+	case len(template.parts) == 2:
+		return template.parts[0] + what + template.parts[1], true
+	case len(template.parts) == 3:
+		if text, ok := value.(string); ok {
+			return template.parts[0] + what + template.parts[1] + text + template.parts[2], true
+		}
+	}
+	return "", false
+}
+
+// verbs returns the number of formatting verbs in the Text of this Error
+func (e Error) verbs() int {
+	if e.template != nil && e.template.text == e.Text {
+		return e.template.verbs
+	}
+	return countVerbs(e.Text)
+}
+
+// countVerbs returns the number of formatting verbs in the given text
+func countVerbs(text string) int {
+	return strings.Count(text, "%") - 2*strings.Count(text, "%%")
+}
+
+// ShowCorrelationID tells if the Correlation ID of errors should be shown in their message, like: "Not Found (ref: 1234)"
+//
+// By default, the Correlation ID is not shown.
+func ShowCorrelationID(show bool) {
+	showCorrelationID.Store(show)
+}
+
+var showCorrelationID atomic.Bool
+
+// ShowAllStacks tells if %+v should also show the StackTrace of the causes of errors, not only their own
+//
+// The frames a cause has in common with the StackTrace shown before it are not repeated, like: "... 5 more".
+// Thus the location where the original failure happened is never hidden by wrapping it.
+//
+// By default, only the StackTrace of the error itself is shown.
+func ShowAllStacks(show bool) {
+	showAllStacks.Store(show)
+}
+
+var showAllStacks atomic.Bool
+
+// formatCauseStacks writes the StackTrace of each cause of this Error, without the frames in common with the previous one
+func (e Error) formatCauseStacks(state fmt.State) {
+	previous := e.Stack.visible()
+	for _, err := range Chain(e)[1:] {
+		cause, ok := asError(err)
+		if !ok || len(cause.Stack) == 0 {
+			continue
+		}
+		stack := cause.Stack.visible()
+		common := stack.commonSuffix(previous)
+		_, _ = io.WriteString(state, "\nCaused by: ")
+		if len(cause.ID) > 0 {
+			_, _ = io.WriteString(state, cause.ID)
+		} else {
+			_, _ = io.WriteString(state, cause.message())
+		}
+		stack[:len(stack)-common].Format(state, 'v')
+		if common > 0 {
+			_, _ = fmt.Fprintf(state, "\n\t... %d more", common)
+		}
+		previous = stack
 	}
-	return sb.String()
 }
 
 // GoString returns the Go syntax of this Error
 //
 // implements fmt.GoStringer
 func (e Error) GoString() string {
+	return e.bounded().goString(nil)
+}
+
+// goString returns the Go syntax of this Error, the Stack is not repeated if it is the same as the parent's
+func (e Error) goString(parent StackTrace) string {
 	var sb strings.Builder
 
 	_, _ = fmt.Fprintf(&sb, `errors.Error{Code: %d, ID: "%s", Text: "%s"`, e.Code, e.ID, e.Text)
@@ -210,9 +811,53 @@ func (e Error) GoString() string {
 	if e.Value != nil {
 		_, _ = fmt.Fprintf(&sb, `, Value: %#v`, e.Value)
 	}
+	if len(e.Pointer) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, Pointer: "%s"`, e.Pointer)
+	}
+	if len(e.URL) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, URL: "%s"`, e.URL)
+	}
+	if len(e.Severity) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, Severity: "%s"`, e.Severity)
+	}
+	if e.Retryable {
+		_, _ = sb.WriteString(`, Retryable: true`)
+	}
+	if len(e.Hint) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, Hint: "%s"`, e.Hint)
+	}
+	if len(e.DocURL) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, DocURL: "%s"`, e.DocURL)
+	}
+	if len(e.CorrelationID) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, CorrelationID: "%s"`, e.CorrelationID)
+	}
+	if len(e.Operation) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, Operation: "%s"`, e.Operation)
+	}
+	if len(e.Component) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, Component: "%s"`, e.Component)
+	}
+	if e.Service != nil {
+		_, _ = fmt.Fprintf(&sb, `, Service: %#v`, e.Service)
+	}
+	if e.Build != nil {
+		_, _ = fmt.Fprintf(&sb, `, Build: %#v`, e.Build)
+	}
+	if e.RateLimit != nil {
+		_, _ = fmt.Fprintf(&sb, `, RateLimit: %#v`, e.RateLimit)
+	}
+	if e.Range != nil {
+		_, _ = fmt.Fprintf(&sb, `, Range: %#v`, e.Range)
+	}
+	if len(e.Attributes) > 0 {
+		_, _ = fmt.Fprintf(&sb, `, Attributes: %#v`, e.Attributes)
+	}
 	if e.Cause != nil {
 		_, _ = sb.WriteString(", Cause: ")
-		if gostringer, ok := e.Cause.(fmt.GoStringer); ok {
+		if cause, ok := asError(e.Cause); ok {
+			_, _ = sb.WriteString(cause.goString(e.Stack))
+		} else if gostringer, ok := e.Cause.(fmt.GoStringer); ok {
 			_, _ = sb.WriteString(gostringer.GoString())
 		} else {
 			_, _ = sb.WriteString(`"`)
@@ -220,7 +865,9 @@ func (e Error) GoString() string {
 			_, _ = sb.WriteString(`"`)
 		}
 	}
-	if len(e.Stack) > 0 {
+	if len(e.Stack) > 0 && slices.Equal(e.Stack, parent) {
+		_, _ = sb.WriteString(`, Stack: /* same as parent */`)
+	} else if len(e.Stack) > 0 {
 		_, _ = fmt.Fprintf(&sb, `, Stack: %#v`, e.Stack)
 	}
 	_, _ = sb.WriteString("}")
@@ -229,13 +876,33 @@ func (e Error) GoString() string {
 
 // Format interprets fmt State and rune to generate an output for fmt.Sprintf, etc
 //
+// %-v writes this Error and its causes on a single line, see SetCompactCauseSeparator.
+//
+// % v (or % s) writes only this Error, without its causes, like for UI messages or metrics labels.
+//
 // implements fmt.Formatter
 func (e Error) Format(state fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if state.Flag('+') {
 			_, _ = io.WriteString(state, e.Error())
+			if len(e.Hint) > 0 {
+				_, _ = io.WriteString(state, "\nHint: ")
+				_, _ = io.WriteString(state, e.Hint)
+			}
+			if len(e.DocURL) > 0 {
+				_, _ = io.WriteString(state, "\nsee: ")
+				_, _ = io.WriteString(state, e.DocURL)
+			}
 			e.Stack.Format(state, verb)
+			if _, isMulti := e.Cause.(*MultiError); isMulti || showAllStacks.Load() {
+				e.formatCauseStacks(state) // the errors of a MultiError are unrelated, each StackTrace matters
+			}
+			return
+		}
+		if state.Flag('-') {
+			_, compact := e.causeSeparators()
+			_, _ = io.WriteString(state, e.text(compact, true))
 			return
 		}
 		if state.Flag('#') {
@@ -244,66 +911,208 @@ func (e Error) Format(state fmt.State, verb rune) {
 		}
 		fallthrough
 	case 's':
+		if state.Flag(' ') {
+			top := e
+			top.Cause = nil
+			_, _ = io.WriteString(state, top.Error())
+			return
+		}
 		_, _ = io.WriteString(state, e.Error())
 	case 'q':
 		_, _ = fmt.Fprintf(state, "%q", e.Error())
 	}
 }
 
+// EnableStackMarshaling tells if MarshalJSON should add the StackTrace of errors in a "stack" array
+//
+// When a cause has the same StackTrace as its parent, like the errors created by WrapErrors,
+// the StackTrace is not repeated and "same_stack" is true instead.
+//
+// By default, the StackTrace is not marshaled.
+func EnableStackMarshaling(enable bool) {
+	stackMarshaling.Store(enable)
+}
+
+var stackMarshaling atomic.Bool
+
+// EnableCausesArrayMarshaling tells if MarshalJSON should always marshal the causes of errors in a "causes" array
+//
+// By default, a single cause is marshaled in a "cause" object and several causes in a "causes" array.
+//
+// UnmarshalJSON accepts both forms, whatever this setting is.
+func EnableCausesArrayMarshaling(enable bool) {
+	causesArrayMarshaling.Store(enable)
+}
+
+var causesArrayMarshaling atomic.Bool
+
+// JSONSchemaVersion is the version of the JSON representation of errors
+//
+// It is marshaled in the "version" property of the outermost error, so the format can evolve
+// while services using different versions of this package keep interoperating.
+const JSONSchemaVersion = 1
+
 // MarshalJSON marshals this into JSON
+//
+// If this Error has several causes, they are marshaled in a "causes" array, see EnableCausesArrayMarshaling.
+//
+// The outermost error also gets a "version" property, see JSONSchemaVersion.
 func (e Error) MarshalJSON() ([]byte, error) {
+	return e.bounded().marshalJSON(nil, JSONSchemaVersion)
+}
+
+// marshalJSON marshals this Error into JSON, the Stack is not repeated if it is the same as the parent's
+//
+// The version is marshaled only if it is not 0, i.e. for the outermost error.
+func (e Error) marshalJSON(parent StackTrace, version int) ([]byte, error) {
 	type surrogate Error
-	var payload interface{}
-	var cause *Error
+	var cause json.RawMessage
+	var causes []json.RawMessage
+	var stack StackTrace
+	var sameStack bool
 
-	if e.Cause != nil {
-		if value, ok := e.Cause.(Error); ok {
-			cause = &value
+	if stackMarshaling.Load() && len(e.Stack) > 0 {
+		if slices.Equal(e.Stack, parent) {
+			sameStack = true
 		} else {
-			var id strings.Builder
-			causeType := reflect.TypeOf(e.Cause)
-			if causeType.Kind() == reflect.Ptr {
-				causeType = causeType.Elem()
+			stack = e.Stack
+		}
+	}
+	if multi, ok := e.Cause.(*MultiError); ok && multi != nil {
+		causes = make([]json.RawMessage, 0, len(multi.Errors))
+		for _, err := range multi.Errors {
+			data, err := toJSONCause(err).marshalJSON(e.Stack, 0)
+			if err != nil {
+				return nil, err
 			}
-			_, _ = id.WriteString("error.runtime")
-			if causeType.PkgPath() != "errors" || causeType.Name() != "errorString" {
-				_, _ = id.WriteString(".")
-				_, _ = id.WriteString(causeType.String())
+			causes = append(causes, data)
+		}
+	} else if e.Cause != nil {
+		data, err := toJSONCause(e.Cause).marshalJSON(e.Stack, 0)
+		if err != nil {
+			return nil, err
+		}
+		if causesArrayMarshaling.Load() {
+			causes = []json.RawMessage{data}
+		} else {
+			cause = data
+		}
+	}
+
+	var truncated bool
+	if limit := e.lengthLimit(); limit > 0 {
+		// the Text is a template, cutting it could break its verbs
+		var cut bool
+		if e.What, cut = truncateText(e.What, limit); cut {
+			e.What += "..."
+			truncated = true
+		}
+		if value, ok := e.Value.(string); ok {
+			if value, cut = truncateText(value, limit); cut {
+				e.Value = value + "..."
+				truncated = true
 			}
-			cause = &Error{Code: http.StatusInternalServerError, ID: id.String(), Text: e.Cause.Error()}
 		}
 	}
 
-	payload = struct {
-		Type string `json:"type"`
+	payload := struct {
+		Type    string `json:"type"`
+		Version int    `json:"version,omitempty"`
 		surrogate
-		Cause *Error `json:"cause,omitempty"`
+		Cause     json.RawMessage   `json:"cause,omitempty"`
+		Causes    []json.RawMessage `json:"causes,omitempty"`
+		Stack     StackTrace        `json:"stack,omitempty"`
+		SameStack bool              `json:"same_stack,omitempty"`
+		Truncated bool              `json:"truncated,omitempty"`
 	}{
 		Type:      "error",
+		Version:   version,
 		surrogate: surrogate(e),
 		Cause:     cause,
+		Causes:    causes,
+		Stack:     stack,
+		SameStack: sameStack,
+		Truncated: truncated,
 	}
 	data, err := json.Marshal(payload)
+	if err == nil && len(e.Extensions) > 0 {
+		data, err = marshalExtensions(data, e.Extensions)
+	}
 	return data, JSONMarshalError.Wrap(err)
 }
 
+// toJSONCause converts the given cause into an Error that can be marshaled
+func toJSONCause(cause error) Error {
+	if value, ok := asError(cause); ok {
+		return value
+	}
+	var id strings.Builder
+	causeType := reflect.TypeOf(cause)
+	if causeType.Kind() == reflect.Ptr {
+		causeType = causeType.Elem()
+	}
+	_, _ = id.WriteString("error.runtime")
+	if causeType.PkgPath() != "errors" || causeType.Name() != "errorString" {
+		_, _ = id.WriteString(".")
+		_, _ = id.WriteString(causeType.String())
+	}
+	return Error{Code: http.StatusInternalServerError, ID: id.String(), Text: cause.Error()}
+}
+
 // UnmarshalJSON decodes JSON
+//
+// If the ID matches a registered sentinel, the missing Code, Text, and DocURL are taken from that sentinel.
+//
+// The causes can be given in a "cause" object, a "cause" array, or a "causes" array.
+// Several causes are collected in a MultiError, like WithCause does.
+//
+// Payloads of any version are accepted: payloads without a "version" were written before JSONSchemaVersion existed,
+// and the properties that are unknown to this version, like the ones added by newer versions, are kept in the Extensions.
 func (e *Error) UnmarshalJSON(payload []byte) (err error) {
 	type surrogate Error
 	var inner struct {
 		Type string `json:"type"`
 		surrogate
-		Cause *Error `json:"cause,omitempty"`
+		Cause  json.RawMessage `json:"cause,omitempty"`
+		Causes []Error         `json:"causes,omitempty"`
 	}
 	if err = json.Unmarshal(payload, &inner); err != nil {
-		return JSONUnmarshalError.Wrap(err)
+		return FromJSON(err, payload)
 	}
 	if inner.Type != "error" {
 		return JSONUnmarshalError.Wrap(InvalidType.With("error", inner.Type))
 	}
 	*e = Error(inner.surrogate)
-	if inner.Cause != nil {
-		e.Cause = *inner.Cause
+	if e.Extensions, err = unmarshalExtensions(payload); err != nil {
+		return FromJSON(err, payload)
+	}
+	if sentinel, found := Lookup(e.ID); found {
+		if e.Code == 0 {
+			e.Code = sentinel.Code
+		}
+		if len(e.Text) == 0 {
+			e.Text = sentinel.Text
+		}
+		if len(e.DocURL) == 0 {
+			e.DocURL = sentinel.DocURL
+		}
+		e.parents = sentinel.parents
+	}
+	if cause := bytes.TrimSpace(inner.Cause); len(cause) > 0 && cause[0] == '[' {
+		var causes []Error
+		if err = json.Unmarshal(cause, &causes); err != nil {
+			return FromJSON(err, payload)
+		}
+		inner.Causes = append(causes, inner.Causes...)
+	} else if len(cause) > 0 && !bytes.Equal(cause, []byte("null")) {
+		var single Error
+		if err = json.Unmarshal(cause, &single); err != nil {
+			return FromJSON(err, payload)
+		}
+		e.Cause = single
+	}
+	for _, cause := range inner.Causes {
+		*e = e.WithCause(cause)
 	}
 	return nil
 }
@@ -10,7 +10,6 @@ import (
 	"net/url"
 	"os"
 	"reflect"
-	"regexp"
 	"strings"
 	"testing"
 
@@ -476,7 +475,7 @@ func (suite *ErrorsSuite) TestFailsWithNonErrorTarget() {
 }
 
 func (suite *ErrorsSuite) TestCanMarshalError() {
-	expected := `{"type": "error", "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key", "value": "value"}`
+	expected := `{"type": "error", "version": 1, "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key", "value": "value"}`
 	testerr := errors.ArgumentInvalid.With("key", "value")
 	payload, err := json.Marshal(testerr)
 	suite.Require().Nil(err)
@@ -484,7 +483,7 @@ func (suite *ErrorsSuite) TestCanMarshalError() {
 }
 
 func (suite *ErrorsSuite) TestCanMarshalErrorWithoutValue() {
-	expected := `{"type": "error", "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key"}`
+	expected := `{"type": "error", "version": 1, "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key"}`
 	testerr := errors.ArgumentInvalid.With("key")
 	payload, err := json.Marshal(testerr)
 	suite.Require().Nil(err)
@@ -494,6 +493,7 @@ func (suite *ErrorsSuite) TestCanMarshalErrorWithoutValue() {
 func (suite *ErrorsSuite) TestCanMarshalErrorWithCause() {
 	expected := `{
 		"type": "error",
+		"version": 1,
 		"id": "error.argument.invalid",
 		"code": 400,
 		"text": "Argument %s is invalid (value: %v)",
@@ -516,6 +516,7 @@ func (suite *ErrorsSuite) TestCanMarshalErrorWithCause() {
 func (suite *ErrorsSuite) TestCanMarshalErrorWithURLErrorCause01() {
 	expected := `{
 		"type": "error",
+		"version": 1,
 		"id": "error.argument.invalid",
 		"code": 400,
 		"text": "Argument %s is invalid (value: %v)",
@@ -546,6 +547,7 @@ func (suite *ErrorsSuite) TestCanMarshalErrorWithURLErrorCause01() {
 func (suite *ErrorsSuite) TestCanMarshalErrorWithURLErrorCause02() {
 	expected := `{
 		"type": "error",
+		"version": 1,
 		"id": "error.argument.invalid",
 		"code": 400,
 		"text": "Argument %s is invalid (value: %v)",
@@ -585,6 +587,7 @@ func (suite *ErrorsSuite) TestCanMarshalErrorWithURLErrorCause02() {
 func (suite *ErrorsSuite) TestCanMarshalErrorWithManyCauses() {
 	expected := `{
 		"type": "error",
+		"version": 1,
 		"id": "error.argument.invalid",
 		"code": 400,
 		"text": "Argument %s is invalid (value: %v)",
@@ -624,6 +627,25 @@ func (suite *ErrorsSuite) TestCanUnmarshalError() {
 	suite.Assert().Equal("error.argument.invalid", testerr.ID)
 }
 
+func (suite *ErrorsSuite) TestCanUnmarshalErrorWithAnyVersion() {
+	payload := `{"type": "error", "version": 42, "id": "error.argument.invalid", "code": 400, "what": "key", "value": "value", "novelty": {"enabled": true}}`
+	testerr := errors.Error{}
+	suite.Require().NoError(json.Unmarshal([]byte(payload), &testerr))
+	suite.Assert().ErrorIs(testerr, errors.ArgumentInvalid)
+	suite.Assert().Equal("Argument key is invalid (value: value)", testerr.Error())
+
+	payload = `{"type": "error", "version": "2.0-beta", "id": "error.argument.invalid"}`
+	suite.Require().NoError(json.Unmarshal([]byte(payload), &testerr))
+	suite.Assert().ErrorIs(testerr, errors.ArgumentInvalid)
+}
+
+func (suite *ErrorsSuite) TestShouldMarshalVersionOnlyOnOutermostError() {
+	payload, err := json.Marshal(errors.ArgumentMissing.Wrap(errors.NotFound.With("user", "john")))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(1, strings.Count(string(payload), `"version":`), "only the outermost error should have a version")
+	suite.Assert().Contains(string(payload), fmt.Sprintf(`"version":%d`, errors.JSONSchemaVersion))
+}
+
 func (suite *ErrorsSuite) TestCanUnmarshalErrorWithErrorCause() {
 	payload := `{
 		"type": "error",
@@ -682,6 +704,40 @@ func (suite *ErrorsSuite) TestCanUnmarshalErrorWithTextCause() {
 	suite.Assert().Equal(`Get "https://bogus.example.com/": Dial tcp: lookup bogus.example.com on 208.67.222.222:53: no such host`, cause.Text)
 }
 
+func (suite *ErrorsSuite) TestCanUnmarshalErrorWithCausesArray() {
+	payloads := []string{
+		`{"type": "error", "id": "error.argument.invalid", "causes": [{"type": "error", "id": "error.argument.missing"}, {"type": "error", "id": "error.notfound"}]}`,
+		`{"type": "error", "id": "error.argument.invalid", "cause": [{"type": "error", "id": "error.argument.missing"}, {"type": "error", "id": "error.notfound"}]}`,
+		`{"type": "error", "id": "error.argument.invalid", "cause": [{"type": "error", "id": "error.argument.missing"}], "causes": [{"type": "error", "id": "error.notfound"}]}`,
+	}
+	for _, payload := range payloads {
+		var testerr errors.Error
+		suite.Require().NoError(json.Unmarshal([]byte(payload), &testerr), payload)
+		causes := testerr.Causes()
+		suite.Require().Len(causes, 2, payload)
+		suite.Assert().ErrorIs(causes[0], errors.ArgumentMissing, payload)
+		suite.Assert().ErrorIs(causes[1], errors.NotFound, payload)
+	}
+
+	var testerr errors.Error
+	suite.Require().NoError(json.Unmarshal([]byte(`{"type": "error", "id": "error.argument.invalid", "causes": [{"type": "error", "id": "error.notfound"}]}`), &testerr))
+	suite.Assert().ErrorIs(testerr.Cause, errors.NotFound, "a single cause should not be a MultiError")
+	suite.Assert().Error(json.Unmarshal([]byte(`{"type": "error", "id": "error.argument.invalid", "cause": [{"type": "blob"}]}`), &testerr))
+}
+
+func (suite *ErrorsSuite) TestCanMarshalErrorWithCausesArray() {
+	defer errors.EnableCausesArrayMarshaling(false)
+	errors.EnableCausesArrayMarshaling(true)
+	payload, err := json.Marshal(errors.ArgumentInvalid.Wrap(errors.NotFound.With("user", "john")))
+	suite.Require().NoError(err)
+	suite.Assert().NotContains(string(payload), `"cause":`)
+	suite.Assert().Contains(string(payload), `"causes":[{"type":"error","code":404,"id":"error.notfound"`)
+
+	var testerr errors.Error
+	suite.Require().NoError(json.Unmarshal(payload, &testerr))
+	suite.Assert().ErrorIs(testerr.Cause, errors.NotFound)
+}
+
 func (suite *ErrorsSuite) TestCanUnmarshalErrorWithManyCauses() {
 	payload := `{
 		"type": "error",
@@ -745,6 +801,23 @@ func (suite *ErrorsSuite) TestCanUnmarshalErrorWithManyCauses() {
 	suite.Assert().Equal("some obscure error", c2.Text)
 }
 
+func (suite *ErrorsSuite) TestCanUnmarshalErrorFromSentinelID() {
+	payload := `{"type": "error", "id": "error.argument.invalid", "what": "key", "value": "value", "cause": {"type": "error", "id": "error.notfound", "text": "Nothing %s was found"}}`
+	testerr := errors.Error{}
+	err := json.Unmarshal([]byte(payload), &testerr)
+	suite.Require().Nil(err)
+	suite.Assert().ErrorIs(testerr, errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(testerr, errors.NotFound)
+	suite.Assert().Equal(errors.ArgumentInvalid.Code, testerr.Code)
+	suite.Assert().Equal(errors.ArgumentInvalid.Text, testerr.Text)
+	suite.Assert().Equal("Argument key is invalid (value: value)\nCaused by:\n\tNothing  was found", testerr.Error())
+
+	cause, ok := testerr.Cause.(errors.Error)
+	suite.Require().True(ok, "cause should be an errors.Error")
+	suite.Assert().Equal(errors.NotFound.Code, cause.Code)
+	suite.Assert().Equal("Nothing %s was found", cause.Text, "existing Text should be kept")
+}
+
 func (suite *ErrorsSuite) TestFailsUnmarshallErrorWithWrongPayload() {
 	payload := `{"type": "error", "id": 1000, "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key", "value": "value"}`
 	testerr := errors.Error{}
@@ -784,6 +857,375 @@ func (suite *ErrorsSuite) TestCanWrapIfNotMe() {
 	suite.Assert().Nil(err, "error should be nil")
 }
 
+func (suite *ErrorsSuite) TestCanAddCorrelationID() {
+	err := errors.NotFound.WithCorrelationID("1234").With("user", "john")
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().Equal("user john Not Found", err.Error())
+	suite.Assert().Empty(errors.NotFound.CorrelationID, "NotFound should not have changed")
+
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("1234", details.CorrelationID)
+
+	errors.ShowCorrelationID(true)
+	defer errors.ShowCorrelationID(false)
+	suite.Assert().Equal("user john Not Found (ref: 1234)", err.Error())
+}
+
+func (suite *ErrorsSuite) TestCanMarshalErrorWithCorrelationID() {
+	expected := `{"type": "error", "version": 1, "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key", "value": "value", "correlation_id": "1234"}`
+	testerr := errors.ArgumentInvalid.WithCorrelationID("1234").With("key", "value")
+	payload, err := json.Marshal(testerr)
+	suite.Require().Nil(err)
+	suite.Assert().JSONEq(expected, string(payload))
+
+	var unmarshaled errors.Error
+	err = json.Unmarshal(payload, &unmarshaled)
+	suite.Require().Nil(err)
+	suite.Assert().Equal("1234", unmarshaled.CorrelationID)
+}
+
+func (suite *ErrorsSuite) TestCanGetFields() {
+	err := errors.ArgumentInvalid.WithCorrelationID("1234")
+	err.Attributes = map[string]interface{}{"tenant": "acme", "id": "ignored"}
+	fields := err.With("key", "value").(errors.Error).Fields()
+	suite.Assert().Equal(map[string]interface{}{
+		"id":             "error.argument.invalid",
+		"code":           400,
+		"message":        "Argument key is invalid (value: value)",
+		"what":           "key",
+		"value":          "value",
+		"correlation_id": "1234",
+		"tenant":         "acme",
+	}, fields)
+}
+
+func (suite *ErrorsSuite) TestCanAddOperationAndComponent() {
+	err := errors.NotFound.WithOperation("db.query").WithComponent("billing").With("invoice", "1234")
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().Empty(errors.NotFound.Operation, "NotFound should not have changed")
+	suite.Assert().Empty(errors.NotFound.Component, "NotFound should not have changed")
+
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("db.query", details.Operation)
+	suite.Assert().Equal("billing", details.Component)
+	suite.Assert().Equal("db.query", details.Fields()["operation"])
+	suite.Assert().Equal("billing", details.Fields()["component"])
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.notfound", "code": 404, "text": "%s %s Not Found", "what": "invoice", "value": "1234", "operation": "db.query", "component": "billing"}`, string(payload))
+}
+
+func (suite *ErrorsSuite) TestCanAddFields() {
+	sentinel := errors.NotFound.WithField("tenant", "acme")
+	err := sentinel.WithFields(map[string]interface{}{"user": "john", "attempt": 3}).With("invoice", "1234")
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().Empty(errors.NotFound.Attributes, "NotFound should not have changed")
+	suite.Assert().Equal(map[string]interface{}{"tenant": "acme"}, sentinel.Attributes, "sentinel should not have changed")
+
+	details := errors.NotFound.Clone()
+	suite.Require().ErrorAs(errors.WrapErrors(errors.ArgumentInvalid.With("key"), err), &details)
+	suite.Assert().Equal(map[string]interface{}{"tenant": "acme", "user": "john", "attempt": 3}, details.Attributes)
+	suite.Assert().Equal("john", details.Fields()["user"])
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.notfound", "code": 404, "text": "%s %s Not Found", "what": "invoice", "value": "1234", "attributes": {"tenant": "acme", "user": "john", "attempt": 3}}`, string(payload))
+}
+
+func (suite *ErrorsSuite) TestCanAddHint() {
+	requireStack(suite.T())
+	err := errors.EnvironmentMissing.WithHint("set the GOOGLE_APPLICATION_CREDENTIALS environment variable").With("GOOGLE_APPLICATION_CREDENTIALS")
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.EnvironmentMissing)
+	suite.Assert().Empty(errors.EnvironmentMissing.Hint, "EnvironmentMissing should not have changed")
+	suite.Assert().Equal("Environment variable GOOGLE_APPLICATION_CREDENTIALS is missing", err.Error())
+	suite.Assert().True(strings.HasPrefix(fmt.Sprintf("%+v", err), "Environment variable GOOGLE_APPLICATION_CREDENTIALS is missing\nHint: set the GOOGLE_APPLICATION_CREDENTIALS environment variable\n"))
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.environment.missing", "code": 400, "text": "Environment variable %s is missing", "what": "GOOGLE_APPLICATION_CREDENTIALS", "hint": "set the GOOGLE_APPLICATION_CREDENTIALS environment variable"}`, string(payload))
+	suite.Assert().Equal("set the GOOGLE_APPLICATION_CREDENTIALS environment variable", errors.Sanitize(err).(errors.Error).Hint)
+}
+
+func (suite *ErrorsSuite) TestCanOverrideCodeIDAndText() {
+	cause := errors.New("connection reset")
+	original := errors.HTTPInternalServerError.Wrap(cause).(errors.Error)
+	err := original.WithCode(http.StatusBadGateway).WithID("error.upstream.failed").WithText("Upstream failed")
+	suite.Assert().Equal(http.StatusBadGateway, err.Code)
+	suite.Assert().Equal("error.upstream.failed", err.ID)
+	suite.Assert().True(strings.HasPrefix(err.Error(), "Upstream failed\nCaused by:"), err.Error())
+	suite.Assert().Equal(original.Stack, err.Stack, "The stack should be preserved")
+	suite.Assert().ErrorIs(err, cause)
+	suite.Assert().ErrorIs(err, errors.Error{ID: "error.upstream.failed"})
+	suite.Assert().NotErrorIs(err, errors.HTTPInternalServerError)
+	suite.Assert().Equal(http.StatusInternalServerError, errors.HTTPInternalServerError.Code, "HTTPInternalServerError should not have changed")
+	suite.Assert().Equal(http.StatusInternalServerError, original.Code, "The original error should not have changed")
+}
+
+func (suite *ErrorsSuite) TestCanSetSeverityAndRetryable() {
+	err := errors.Timeout.WithSeverity(errors.SeverityCritical).WithRetryable(true).With("database")
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal(errors.SeverityCritical, details.Severity)
+	suite.Assert().True(details.Retryable)
+	suite.Assert().Empty(errors.Timeout.Severity, "Timeout should not have changed")
+	suite.Assert().Equal("error", errors.Timeout.Severity.String())
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.timeout", "code": 408, "text": "%s Timeout", "what": "database", "severity": "critical", "retryable": true}`, string(payload))
+}
+
+func (suite *ErrorsSuite) TestCanAddCauses() {
+	err := errors.CreationFailed.
+		WithCause(errors.ArgumentInvalid.With("key", "value")).
+		WithCause(nil).
+		WithCause(errors.ArgumentMissing.With("key")).
+		WithCause(fmt.Errorf("some simple string error"))
+	suite.Assert().Nil(errors.CreationFailed.Cause, "CreationFailed should not have changed")
+	suite.Require().Len(err.Causes(), 3)
+	suite.Assert().ErrorIs(err, errors.CreationFailed)
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+
+	details := errors.ArgumentMissing.Clone()
+	suite.Require().ErrorAs(err, &details)
+	suite.Assert().Equal("key", details.What)
+
+	single := errors.CreationFailed.WithCause(errors.ArgumentMissing.With("key"))
+	suite.Assert().Len(single.Causes(), 1)
+	suite.Assert().ErrorIs(single.Unwrap(), errors.ArgumentMissing)
+	suite.Assert().Nil(errors.CreationFailed.Causes())
+
+	more := err.WithCause(errors.NotFound.With("user", "john"))
+	suite.Assert().Len(more.Causes(), 4)
+	suite.Assert().Len(err.Causes(), 3, "err should not have changed")
+}
+
+func (suite *ErrorsSuite) TestCanMarshalErrorWithMultipleCauses() {
+	expected := `{
+		"type": "error",
+		"version": 1,
+		"id": "error.creation.failed",
+		"code": 500,
+		"text": "Failed Creating %s",
+		"what": "user",
+		"causes": [
+			{"type": "error", "id": "error.argument.missing", "code": 400, "text": "Argument %s is missing", "what": "key"},
+			{"type": "error", "id": "error.runtime", "code": 500, "text": "some simple string error"}
+		]
+	}`
+	testerr := errors.CreationFailed.With("user").(errors.Error).
+		WithCause(errors.ArgumentMissing.With("key")).
+		WithCause(fmt.Errorf("some simple string error"))
+	payload, err := json.Marshal(testerr)
+	suite.Require().Nil(err)
+	suite.Assert().JSONEq(expected, string(payload))
+
+	var unmarshaled errors.Error
+	suite.Require().Nil(json.Unmarshal(payload, &unmarshaled))
+	suite.Require().Len(unmarshaled.Causes(), 2)
+	suite.Assert().ErrorIs(unmarshaled, errors.ArgumentMissing)
+	suite.Assert().ErrorIs(unmarshaled, errors.RuntimeError)
+}
+
+func (suite *ErrorsSuite) TestCanSetCauseSeparator() {
+	defer errors.SetCauseSeparator(errors.DefaultCauseSeparator)
+	errors.SetCauseSeparator(" | ")
+	err := errors.CreationFailed.With("user").(errors.Error).WithCause(errors.ArgumentMissing.With("name"))
+	suite.Assert().Equal("Failed Creating user | Argument name is missing", err.Error())
+	suite.Assert().Equal("Failed Creating user | Argument name is missing", fmt.Sprintf("%v", err))
+}
+
+func (suite *ErrorsSuite) TestCanFormatOnASingleLine() {
+	err := errors.CreationFailed.With("user").(errors.Error).WithCause(errors.JSONMarshalError.Wrap(errors.ArgumentMissing.With("name")))
+	suite.Assert().Equal("Failed Creating user: JSON failed to marshal data: Argument name is missing", fmt.Sprintf("%-v", err))
+
+	err = errors.CreationFailed.With("user").(errors.Error).WithCause(errors.ArgumentMissing.With("name")).WithCause(errors.NotFound.With("group", "admins"))
+	suite.Assert().Equal("Failed Creating user: 2 errors: [Argument name is missing; group admins Not Found]", fmt.Sprintf("%-v", err))
+	suite.Assert().Equal("Failed Creating user\nCaused by:\n\t2 errors:\nArgument name is missing\ngroup admins Not Found", err.Error())
+
+	defer errors.SetCompactCauseSeparator(errors.DefaultCompactCauseSeparator)
+	errors.SetCompactCauseSeparator(" <- ")
+	suite.Assert().Equal("Failed Creating user <- 2 errors: [Argument name is missing; group admins Not Found]", fmt.Sprintf("%-v", err))
+}
+
+func (suite *ErrorsSuite) TestCanFormatWithoutCauses() {
+	err := errors.CreationFailed.With("user").(errors.Error).WithCause(errors.JSONMarshalError.Wrap(errors.ArgumentMissing.With("name")))
+	suite.Assert().Equal("Failed Creating user", fmt.Sprintf("% v", err))
+	suite.Assert().Equal("Failed Creating user", fmt.Sprintf("% s", err))
+	suite.Assert().Contains(fmt.Sprintf("%v", err), "Caused by")
+
+	single := errors.NotFound.With("user", "john")
+	suite.Assert().Equal(single.Error(), fmt.Sprintf("% v", single))
+}
+
+func (suite *ErrorsSuite) TestCanGetStackTraceLikePkgErrors() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack()
+	tracer, ok := err.(errors.StackTracer)
+	suite.Require().True(ok, "Error should implement StackTracer")
+	suite.Assert().Equal(err.(errors.Error).Stack, tracer.StackTrace())
+
+	// Reporting tools like Sentry find the frames by reflection as they do not know our types
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	suite.Require().True(method.IsValid(), "StackTrace method should be found by reflection")
+	frames := method.Call(nil)[0]
+	suite.Require().Equal(reflect.Slice, frames.Kind())
+	suite.Require().Equal(reflect.Uintptr, frames.Index(0).Kind())
+	frame := errors.StackFrame(frames.Index(0).Uint())
+	suite.Assert().Equal("github.com/gildas/go-errors_test.(*ErrorsSuite).TestCanGetStackTraceLikePkgErrors", frame.FuncName())
+}
+
+func findUser() error {
+	return errors.NotFound.With("user", "john")
+}
+
+func (suite *ErrorsSuite) TestCanShowAllStacks() {
+	requireStack(suite.T())
+	err := errors.CreationFailed.Wrap(findUser())
+	suite.Assert().NotContains(fmt.Sprintf("%+v", err), "findUser")
+
+	defer errors.ShowAllStacks(false)
+	errors.ShowAllStacks(true)
+	text := fmt.Sprintf("%+v", err)
+	suite.Assert().Contains(text, "\nCaused by: error.notfound\ngithub.com/gildas/go-errors_test.findUser\n")
+	suite.Assert().Regexp(`TestCanShowAllStacks\n\t[^\n]*errors_test.go:[0-9]+\n\t\.\.\. [0-9]+ more$`, text)
+	suite.Assert().Equal(1, strings.Count(text, "testing.tRunner"), "Common frames should not be repeated")
+}
+
+func (suite *ErrorsSuite) TestShouldNotRepeatSameStackInGoString() {
+	requireStack(suite.T())
+	inner := errors.NotFound.With("user", "john").(errors.Error)
+	outer := errors.CreationFailed
+	outer.Stack = inner.Stack
+	outer.Cause = inner
+	text := fmt.Sprintf("%#v", outer)
+	suite.Assert().Equal(1, strings.Count(text, "Stack: []errors.StackFrame{"), text)
+	suite.Assert().Contains(text, "Stack: /* same as parent */")
+}
+
+func (suite *ErrorsSuite) TestCanMarshalStackTraces() {
+	requireStack(suite.T())
+	inner := errors.NotFound.With("user", "john").(errors.Error)
+	outer := errors.CreationFailed
+	outer.Stack = inner.Stack
+	outer.Cause = errors.ArgumentMissing.With("name").(errors.Error).WithCause(inner)
+
+	payload, err := json.Marshal(outer)
+	suite.Require().Nil(err)
+	suite.Assert().NotContains(string(payload), `"stack"`)
+
+	defer errors.EnableStackMarshaling(false)
+	errors.EnableStackMarshaling(true)
+	payload, err = json.Marshal(outer)
+	suite.Require().Nil(err)
+
+	type node struct {
+		Stack     []map[string]interface{} `json:"stack"`
+		SameStack bool                     `json:"same_stack"`
+	}
+	var decoded struct {
+		node
+		Cause struct {
+			node
+			Cause node `json:"cause"`
+		} `json:"cause"`
+	}
+	suite.Require().Nil(json.Unmarshal(payload, &decoded))
+	suite.Assert().Len(decoded.Stack, len(outer.Stack))
+	suite.Assert().NotEmpty(decoded.Cause.Stack)
+	suite.Assert().NotEmpty(decoded.Cause.Cause.Stack)
+	suite.Assert().False(decoded.Cause.Cause.SameStack, "The stack of the cause is not the same as its parent's")
+
+	outer.Cause = inner
+	payload, err = json.Marshal(outer)
+	suite.Require().Nil(err)
+	var shared struct {
+		node
+		Cause node `json:"cause"`
+	}
+	suite.Require().Nil(json.Unmarshal(payload, &shared))
+	suite.Assert().NotEmpty(shared.Stack)
+	suite.Assert().True(shared.Cause.SameStack)
+	suite.Assert().Empty(shared.Cause.Stack)
+}
+
+func (suite *ErrorsSuite) TestShouldNotAllocateWhenRenderingStaticSentinels() {
+	err := errors.NotImplemented
+	allocations := testing.AllocsPerRun(100, func() {
+		_ = err.Error()
+	})
+	suite.Assert().Zero(allocations)
+}
+
+func (suite *ErrorsSuite) TestShouldRenderChangedTextOfSentinels() {
+	err := errors.NotFound
+	suite.Assert().Equal(" %!s(<nil>) Not Found", err.Error())
+	err.Text = "Nothing here"
+	suite.Assert().Equal("Nothing here", err.Error())
+	err = errors.NotImplemented
+	err.Text = "%s is not implemented"
+	err.What = "Flying"
+	suite.Assert().Equal("Flying is not implemented", err.Error())
+}
+
+func (suite *ErrorsSuite) TestShouldRenderSimpleErrorsWithOneAllocation() {
+	err := errors.NotFound.With("user", "john")
+	allocations := testing.AllocsPerRun(100, func() {
+		_ = err.Error()
+	})
+	suite.Assert().Equal(float64(1), allocations)
+	suite.Assert().Equal("user john Not Found", err.Error())
+	suite.Assert().Equal("Argument count is invalid (value: 12)", errors.ArgumentInvalid.With("count", 12).Error())
+}
+
+func (suite *ErrorsSuite) TestCanWrapfWithSentinel() {
+	requireStack(suite.T())
+	cause := errors.NotFound.With("bucket", "photos")
+	err := errors.CreationFailed.Wrapf(cause, "bucket %s", "photos")
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.CreationFailed)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().Equal("Failed Creating bucket photos\nCaused by:\n\tbucket photos Not Found", err.Error())
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+	suite.Assert().Nil(errors.CreationFailed.Wrapf(nil, "bucket %s", "photos"))
+}
+
+func (suite *ErrorsSuite) TestCanCreateWithMessagef() {
+	requireStack(suite.T())
+	err := errors.ArgumentMissing.WithMessagef("header %s", "X-Tenant")
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+	suite.Assert().Equal("Argument header X-Tenant is missing", err.Error())
+	suite.Assert().Equal("header X-Tenant", err.(errors.Error).What)
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+}
+
+func (suite *ErrorsSuite) TestCanChainDecorationsWithWhat() {
+	requireStack(suite.T())
+	cause := errors.Timeout.With("database")
+	err := errors.NotFound.WithWhat("user", "john").WithField("tenant", "acme").WithCause(cause)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().ErrorIs(err, errors.Timeout)
+	suite.Assert().Equal("user john Not Found\nCaused by:\n\tdatabase Timeout", err.Error())
+	suite.Assert().Equal("acme", err.Attributes["tenant"])
+	suite.Require().NotEmpty(err.Stack)
+	suite.Assert().Equal("(*ErrorsSuite).TestCanChainDecorationsWithWhat", fmt.Sprintf("%n", err.Stack[0]))
+}
+
+func (suite *ErrorsSuite) TestShouldUnescapePercentInStaticSentinels() {
+	sentinel := errors.NewSentinel(http.StatusInsufficientStorage, "error.test.disk.percent", "Disk is 100%% full")
+	suite.Assert().Equal("Disk is 100% full", sentinel.Error())
+	suite.Assert().Equal(errors.Error{Text: sentinel.Text}.Error(), sentinel.Error(), "sentinels and literal errors should render the same")
+	suite.Assert().Equal("Disk is 100% full", sentinel.WithStack().Error())
+}
+
 func ExampleError() {
 	err := errors.NewSentinel(500, "error.test.custom", "Test Error").Clone()
 	fmt.Println(err)
@@ -815,75 +1257,6 @@ func ExampleError_Format_quoted() {
 	// "Not Implemented"
 }
 
-func ExampleError_Format_withStack() {
-	output := CaptureStdout(func() {
-		err := errors.NotImplemented.WithStack()
-		if err != nil {
-			fmt.Printf("%+v", err)
-		}
-	})
-	// remove the path of each file and line numbers as they change for each Go deployments
-	lines := strings.Split(output, "\n")
-	simplifier := regexp.MustCompile(`\s*(.*/)?(.*):[0-9]+`)
-	for i := 0; i < len(lines); i++ {
-		line := lines[i]
-		lines[i] = simplifier.ReplaceAllString(line, "  ${2}")
-	}
-	// we also do not care about last line that is machine dependent
-	fmt.Println(strings.Join(lines[0:len(lines)-1], "\n"))
-	// Output:
-	// Not Implemented
-	// github.com/gildas/go-errors_test.ExampleError_Format_withStack.func1
-	//   errors_test.go
-	// github.com/gildas/go-errors_test.CaptureStdout
-	//   errors_test.go
-	// github.com/gildas/go-errors_test.ExampleError_Format_withStack
-	//   errors_test.go
-	// testing.runExample
-	//   run_example.go
-	// testing.runExamples
-	//   example.go
-	// testing.(*M).Run
-	//   testing.go
-	// main.main
-	//   _testmain.go
-	// runtime.main
-	//   proc.go
-	// runtime.goexit
-}
-
-func ExampleError_Format_gosyntax_01() {
-	output := CaptureStdout(func() {
-		err := errors.WrapErrors(errors.ArgumentInvalid.With("key", "value"), errors.ArgumentMissing.With("key"))
-		if err != nil {
-			fmt.Printf("%#v\n", err)
-		}
-	})
-	// remove the line numbers from the stack trace as they change when the code is changed
-	simplifier := regexp.MustCompile(`\.go:[0-9]+`)
-	// we also do not care about the last file which is machine dependent
-	noasm := regexp.MustCompile(`, asm_.[^\.]+.s:[0-9]+`)
-	fmt.Println(noasm.ReplaceAllString(simplifier.ReplaceAllString(output, ".go"), ""))
-	// Output:
-	// errors.Error{Code: 400, ID: "error.argument.invalid", Text: "Argument %s is invalid (value: %v)", What: "key", Value: "value", Cause: errors.Error{Code: 400, ID: "error.argument.missing", Text: "Argument %s is missing", What: "key", Stack: []errors.StackFrame{errors_test.go, errors_test.go, errors_test.go, run_example.go, example.go, testing.go, _testmain.go, proc.go}}, Stack: []errors.StackFrame{errors_test.go, errors_test.go, errors_test.go, run_example.go, example.go, testing.go, _testmain.go, proc.go}}
-}
-
-func ExampleError_Format_gosyntax_02() {
-	output := CaptureStdout(func() {
-		err := errors.WrapErrors(errors.ArgumentInvalid.With("key", "value"), fmt.Errorf("unknown error"))
-		if err != nil {
-			fmt.Printf("%#v\n", err)
-		}
-	})
-	// remove the line numbers from the stack trace as they change when the code is changed
-	simplifier := regexp.MustCompile(`\.go:[0-9]+`)
-	// we also do not care about the last file which is machine dependent
-	noasm := regexp.MustCompile(`, asm_.[^\.]+.s:[0-9]+`)
-	fmt.Println(noasm.ReplaceAllString(simplifier.ReplaceAllString(output, ".go"), ""))
-	// Output:
-	// errors.Error{Code: 400, ID: "error.argument.invalid", Text: "Argument %s is invalid (value: %v)", What: "key", Value: "value", Cause: "unknown error", Stack: []errors.StackFrame{errors_test.go, errors_test.go, errors_test.go, run_example.go, example.go, testing.go, _testmain.go, proc.go}}
-}
-
 func ExampleError_With() {
 	err := errors.ArgumentMissing.With("key")
 	if err != nil {
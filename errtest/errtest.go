@@ -0,0 +1,128 @@
+// Package errtest provides assertions for tests that check the errors of github.com/gildas/go-errors.
+//
+// Example:
+//
+//	func TestFindUser(t *testing.T) {
+//	  _, err := FindUser("john")
+//	  errtest.AssertIs(t, err, errors.NotFound)
+//	  errtest.AssertWhat(t, err, "user")
+//	}
+package errtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gildas/go-errors"
+)
+
+// AssertIs asserts that err's chain matches the given target, see errors.Is
+//
+// AssertIs returns true if the assertion succeeded.
+func AssertIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("Error %q does not match %q", text(err), text(target))
+		return false
+	}
+	return true
+}
+
+// AssertIsNot asserts that err's chain does not match the given target, see errors.Is
+//
+// AssertIsNot returns true if the assertion succeeded.
+func AssertIsNot(t testing.TB, err, target error) bool {
+	t.Helper()
+	if errors.Is(err, target) {
+		t.Errorf("Error %q should not match %q", text(err), text(target))
+		return false
+	}
+	return true
+}
+
+// AssertWhat asserts that the first errors.Error in err's chain has the given What
+//
+// AssertWhat returns true if the assertion succeeded.
+func AssertWhat(t testing.TB, err error, what string) bool {
+	t.Helper()
+	details, ok := first(t, err)
+	if !ok {
+		return false
+	}
+	if details.What != what {
+		t.Errorf("Error %q should be about %q, but it is about %q", text(err), what, details.What)
+		return false
+	}
+	return true
+}
+
+// AssertCode asserts that the first errors.Error in err's chain has the given Code
+//
+// AssertCode returns true if the assertion succeeded.
+func AssertCode(t testing.TB, err error, code int) bool {
+	t.Helper()
+	details, ok := first(t, err)
+	if !ok {
+		return false
+	}
+	if details.Code != code {
+		t.Errorf("Error %q should have code %d, but it has code %d", text(err), code, details.Code)
+		return false
+	}
+	return true
+}
+
+// AssertChain asserts that the errors.Error in err's chain match the given sentinels, in that order
+//
+// The errors that are not errors.Error, like the ones from fmt.Errorf, are ignored.
+//
+// AssertChain returns true if the assertion succeeded.
+func AssertChain(t testing.TB, err error, sentinels ...error) bool {
+	t.Helper()
+	chain := details(err)
+	ids := make([]string, 0, len(chain))
+	for _, details := range chain {
+		ids = append(ids, details.ID)
+	}
+	if len(chain) != len(sentinels) {
+		t.Errorf("Error %q should have %d errors in its chain, but it has %d: [%s]", text(err), len(sentinels), len(chain), strings.Join(ids, ", "))
+		return false
+	}
+	for index, sentinel := range sentinels {
+		if !chain[index].Is(sentinel) {
+			t.Errorf("Error #%d in the chain of %q should match %q, but it is %s: [%s]", index, text(err), text(sentinel), ids[index], strings.Join(ids, ", "))
+			return false
+		}
+	}
+	return true
+}
+
+// first returns the first errors.Error in err's chain, it fails the test if there is none
+func first(t testing.TB, err error) (errors.Error, bool) {
+	t.Helper()
+	if chain := details(err); len(chain) > 0 {
+		return chain[0], true
+	}
+	t.Errorf("Error %q should contain an errors.Error", text(err))
+	return errors.Error{}, false
+}
+
+// details returns the errors.Error in err's chain
+func details(err error) (chain []errors.Error) {
+	for _, link := range errors.Chain(err) {
+		if details, ok := link.(errors.Error); ok {
+			chain = append(chain, details)
+		} else if details, ok := link.(*errors.Error); ok && details != nil {
+			chain = append(chain, *details)
+		}
+	}
+	return
+}
+
+// text returns the text of the given error, even if it is nil
+func text(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
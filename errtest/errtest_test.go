@@ -0,0 +1,74 @@
+package errtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-errors/errtest"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrTestSuite struct {
+	suite.Suite
+}
+
+func TestErrTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrTestSuite))
+}
+
+// recorder is a testing.TB that records the failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (suite *ErrTestSuite) TestCanAssertIs() {
+	t := &recorder{TB: suite.T()}
+	err := errors.CreationFailed.Wrap(errors.NotFound.With("user", "john"))
+	suite.Assert().True(errtest.AssertIs(t, err, errors.NotFound))
+	suite.Assert().True(errtest.AssertIsNot(t, err, errors.Timeout))
+	suite.Assert().Empty(t.failures)
+
+	suite.Assert().False(errtest.AssertIs(t, err, errors.Timeout))
+	suite.Assert().False(errtest.AssertIsNot(t, err, errors.NotFound))
+	suite.Assert().False(errtest.AssertIs(t, nil, errors.NotFound))
+	suite.Require().Len(t.failures, 3)
+	suite.Assert().Equal(`Error "<nil>" does not match " %!s(<nil>) Not Found"`, t.failures[2])
+}
+
+func (suite *ErrTestSuite) TestCanAssertWhatAndCode() {
+	t := &recorder{TB: suite.T()}
+	err := fmt.Errorf("lookup: %w", errors.NotFound.With("user", "john"))
+	suite.Assert().True(errtest.AssertWhat(t, err, "user"))
+	suite.Assert().True(errtest.AssertCode(t, err, 404))
+	suite.Assert().Empty(t.failures)
+
+	suite.Assert().False(errtest.AssertWhat(t, err, "group"))
+	suite.Assert().False(errtest.AssertCode(t, err, 400))
+	suite.Assert().False(errtest.AssertCode(t, fmt.Errorf("plain"), 400))
+	suite.Assert().Equal([]string{
+		`Error "lookup: user john Not Found" should be about "group", but it is about "user"`,
+		`Error "lookup: user john Not Found" should have code 400, but it has code 404`,
+		`Error "plain" should contain an errors.Error`,
+	}, t.failures)
+}
+
+func (suite *ErrTestSuite) TestCanAssertChain() {
+	t := &recorder{TB: suite.T()}
+	err := errors.CreationFailed.Wrap(fmt.Errorf("lookup: %w", errors.NotFound.With("user", "john")))
+	suite.Assert().True(errtest.AssertChain(t, err, errors.CreationFailed, errors.NotFound))
+	suite.Assert().Empty(t.failures)
+
+	suite.Assert().False(errtest.AssertChain(t, err, errors.CreationFailed))
+	suite.Assert().False(errtest.AssertChain(t, err, errors.CreationFailed, errors.Timeout))
+	suite.Require().Len(t.failures, 2)
+	suite.Assert().Contains(t.failures[0], "should have 1 errors in its chain, but it has 2: [error.creation.failed, error.notfound]")
+	suite.Assert().Contains(t.failures[1], "Error #1 in the chain of")
+}
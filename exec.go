@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+)
+
+// StderrExcerptSize is the maximum number of bytes of the standard error of a command kept by FromExec
+const StderrExcerptSize = 1024
+
+// FromExec converts the error returned by running the given command into a CommandFailed error
+//
+// The command line goes to the What of the error, the exit status to its Value,
+// and the end of the standard error of the command, up to StderrExcerptSize bytes, to the "stderr" attribute.
+//
+// The standard error is taken from the exec.ExitError, as filled by cmd.Output(),
+// or from cmd.Stderr if it is a *bytes.Buffer or a *strings.Builder.
+//
+// If the command could not be started, the exit status is -1.
+//
+// The given error becomes the Cause. If err is nil, FromExec returns nil.
+//
+// FromExec also records the stack trace at the point it was called.
+//
+// Example:
+//
+//	cmd := exec.Command("git", "fetch")
+//	if _, err := cmd.Output(); err != nil {
+//	  return errors.FromExec(cmd, err)
+//	}
+func FromExec(cmd *exec.Cmd, err error) error {
+	if err == nil {
+		return nil
+	}
+	final := CommandFailed.derive()
+	final.Value = -1
+	final.Cause = err
+
+	var stderr string
+	var exitError *exec.ExitError
+	if As(err, &exitError) {
+		final.Value = exitError.ExitCode()
+		stderr = string(exitError.Stderr)
+	}
+	if cmd != nil {
+		final.What = commandLine(cmd)
+		if len(stderr) == 0 {
+			if buffer, ok := cmd.Stderr.(fmt.Stringer); ok {
+				stderr = buffer.String()
+			}
+		}
+	}
+	if excerpt := stderrExcerpt(stderr); len(excerpt) > 0 {
+		final.Attributes = map[string]interface{}{"stderr": excerpt}
+	}
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// commandLine gives the command line of the given command
+func commandLine(cmd *exec.Cmd) string {
+	if len(cmd.Args) == 0 {
+		return cmd.Path
+	}
+	return strings.Join(cmd.Args, " ")
+}
+
+// stderrExcerpt gives the last StderrExcerptSize bytes of the given standard error
+//
+// The last lines are usually the ones that tell why the command failed.
+func stderrExcerpt(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) <= StderrExcerptSize {
+		return stderr
+	}
+	start := len(stderr) - StderrExcerptSize
+	for start < len(stderr) && !utf8.RuneStart(stderr[start]) {
+		start++
+	}
+	return "..." + stderr[start:]
+}
@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertExecErrors() {
+	requireStack(suite.T())
+	cmd := exec.Command("sh", "-c", "echo oops >&2; exit 3")
+	_, err := cmd.Output()
+	suite.Require().Error(err)
+
+	err = errors.FromExec(cmd, err)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.CommandFailed)
+	var exitError *exec.ExitError
+	suite.Assert().ErrorAs(err, &exitError)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("sh -c echo oops >&2; exit 3", details.What)
+	suite.Assert().Equal(3, details.Value)
+	suite.Assert().Equal("oops", details.Attributes["stderr"])
+	suite.Assert().NotEmpty(details.Stack)
+	suite.Assert().Nil(errors.FromExec(cmd, nil))
+}
+
+func (suite *ErrorsSuite) TestShouldCapStderrWithFromExec() {
+	stderr := &strings.Builder{}
+	cmd := exec.Command("sh", "-c", "i=0; while [ $i -lt 500 ]; do echo line $i >&2; i=$((i+1)); done; exit 1")
+	cmd.Stderr = stderr
+	err := errors.FromExec(cmd, cmd.Run())
+	suite.Require().Error(err)
+	excerpt, ok := err.(errors.Error).Attributes["stderr"].(string)
+	suite.Require().True(ok, "stderr should be a string")
+	suite.Assert().Equal(errors.StderrExcerptSize+3, len(excerpt))
+	suite.Assert().True(strings.HasPrefix(excerpt, "..."))
+	suite.Assert().True(strings.HasSuffix(excerpt, "line 499"))
+}
+
+func (suite *ErrorsSuite) TestCanConvertExecErrorsWhenCommandDoesNotStart() {
+	cmd := exec.Command("/nonexistent/command")
+	err := errors.FromExec(cmd, cmd.Run())
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.CommandFailed)
+	suite.Assert().Equal(-1, err.(errors.Error).Value)
+	suite.Assert().Nil(err.(errors.Error).Attributes)
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// knownJSONKeys contains the properties of the JSON representation of an Error
+//
+// The other properties are kept in the Extensions of the Error.
+var knownJSONKeys = func() map[string]bool {
+	keys := map[string]bool{"type": true, "version": true, "cause": true, "causes": true, "stack": true, "same_stack": true, "truncated": true}
+	errorType := reflect.TypeOf(Error{})
+	for i := 0; i < errorType.NumField(); i++ {
+		name, _, _ := strings.Cut(errorType.Field(i).Tag.Get("json"), ",")
+		if len(name) > 0 && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}()
+
+// unmarshalExtensions collects the properties of the given JSON object that are not known
+func unmarshalExtensions(payload []byte) (map[string]json.RawMessage, error) {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &properties); err != nil {
+		return nil, err
+	}
+	var extensions map[string]json.RawMessage
+	for key, value := range properties {
+		if knownJSONKeys[key] {
+			continue
+		}
+		if extensions == nil {
+			extensions = map[string]json.RawMessage{}
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// marshalExtensions adds the given extensions to the given JSON object, sorted by key
+func marshalExtensions(data []byte, extensions map[string]json.RawMessage) ([]byte, error) {
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if !knownJSONKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return data, nil
+	}
+	sort.Strings(keys)
+	var buffer bytes.Buffer
+	_, _ = buffer.Write(bytes.TrimSuffix(data, []byte("}")))
+	for _, key := range keys {
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(extensions[key])
+		if err != nil {
+			return nil, err
+		}
+		_ = buffer.WriteByte(',')
+		_, _ = buffer.Write(name)
+		_ = buffer.WriteByte(':')
+		_, _ = buffer.Write(value)
+	}
+	_ = buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
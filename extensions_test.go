@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanKeepUnknownJSONProperties() {
+	payload := `{"type": "error", "version": 3, "id": "error.argument.invalid", "code": 400, "what": "key", "value": "value", "tenant": "acme", "limits": {"max": 10}}`
+	var testerr errors.Error
+	suite.Require().NoError(json.Unmarshal([]byte(payload), &testerr))
+	suite.Assert().ErrorIs(testerr, errors.ArgumentInvalid)
+	suite.Require().Len(testerr.Extensions, 2)
+	suite.Assert().JSONEq(`"acme"`, string(testerr.Extensions["tenant"]))
+	suite.Assert().JSONEq(`{"max": 10}`, string(testerr.Extensions["limits"]))
+
+	data, err := json.Marshal(testerr)
+	suite.Require().NoError(err)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "key", "value": "value", "tenant": "acme", "limits": {"max": 10}}`, string(data))
+}
+
+func (suite *ErrorsSuite) TestCanKeepUnknownJSONPropertiesOfCauses() {
+	payload := `{"type": "error", "id": "error.argument.invalid", "cause": {"type": "error", "id": "error.notfound", "what": "user", "value": "john", "region": "eu"}}`
+	var testerr errors.Error
+	suite.Require().NoError(json.Unmarshal([]byte(payload), &testerr))
+	suite.Assert().Empty(testerr.Extensions)
+	var cause errors.Error
+	suite.Require().ErrorAs(testerr.Cause, &cause)
+	suite.Assert().JSONEq(`"eu"`, string(cause.Extensions["region"]))
+
+	data, err := json.Marshal(testerr)
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(data), `"region":"eu"`)
+}
+
+func (suite *ErrorsSuite) TestShouldNotOverwriteKnownJSONPropertiesWithExtensions() {
+	testerr := errors.NotFound.WithField("user", "john")
+	testerr.Extensions = map[string]json.RawMessage{"id": json.RawMessage(`"error.hacked"`)}
+	data, err := json.Marshal(testerr)
+	suite.Require().NoError(err)
+	suite.Assert().NotContains(string(data), "error.hacked")
+
+	testerr.Extensions = map[string]json.RawMessage{"broken": json.RawMessage(`{"max":`)}
+	_, err = json.Marshal(testerr)
+	suite.Assert().Error(err)
+}
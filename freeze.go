@@ -0,0 +1,50 @@
+package errors
+
+import "sync/atomic"
+
+var freezeSentinels atomic.Bool
+
+// FreezeSentinels tells if the sentinels should be protected against modifications
+//
+// Sentinels are package-level variables, a stray assignment like:
+//
+//	errors.NotFound.What = "user"
+//
+// modifies errors.NotFound for the whole process.
+//
+// When frozen, the methods that derive a new Error from a sentinel, like With, Wrap, or WithStack,
+// panic if the sentinel was modified after NewSentinel created it. The panic tells what was modified, see Diff.
+//
+// Checking sentinels has a cost, this is meant for tests and debug builds.
+//
+// By default, sentinels are not frozen.
+func FreezeSentinels(freeze bool) {
+	freezeSentinels.Store(freeze)
+}
+
+// sentinelSeal records the state of a sentinel when NewSentinel created it
+type sentinelSeal struct {
+	sentinel Error
+}
+
+// derive returns a copy of this Error to be decorated
+//
+// The copy is not a sentinel anymore, so it can be modified freely.
+// When sentinels are frozen, derive panics if this Error is a sentinel that was modified after its creation.
+func (e Error) derive() Error {
+	if e.seal == nil {
+		return e
+	}
+	if freezeSentinels.Load() {
+		if differences := Diff(e.seal.sentinel, e.withoutSeal()); len(differences) > 0 {
+			panic(Errorf("Sentinel %q was modified after its creation:\n%s", e.seal.sentinel.ID, differences))
+		}
+	}
+	return e.withoutSeal()
+}
+
+// withoutSeal returns this Error without its seal
+func (e Error) withoutSeal() Error {
+	e.seal = nil
+	return e
+}
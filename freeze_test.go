@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	"net/http"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanFreezeSentinels() {
+	errors.FreezeSentinels(true)
+	defer errors.FreezeSentinels(false)
+
+	sentinel := errors.NewSentinel(http.StatusNotFound, "error.test.frozen", "Frozen %s not found")
+	suite.Assert().NotPanics(func() { _ = sentinel.With("user", "john") })
+
+	derived := sentinel.WithSeverity(errors.SeverityWarning)
+	derived.What = "user"
+	suite.Assert().NotPanics(func() { _ = derived.WithStack() }, "Errors derived from sentinels can be modified")
+
+	sentinel.What = "user"
+	suite.Assert().PanicsWithError("Sentinel \"error.test.frozen\" was modified after its creation:\nWhat: expected \"\", actual \"user\"", func() { _ = sentinel.WithStack() })
+	suite.Assert().Panics(func() { _ = sentinel.Wrap(errors.New("oops")) })
+	suite.Assert().Panics(func() { _ = sentinel.WithField("key", "value") })
+}
+
+func (suite *ErrorsSuite) TestCanModifySentinelsWhenNotFrozen() {
+	sentinel := errors.NewSentinel(http.StatusNotFound, "error.test.unfrozen", "Unfrozen %s not found")
+	sentinel.Code = http.StatusGone
+	suite.Assert().NotPanics(func() { _ = sentinel.With("user", "john") })
+	suite.Assert().Equal(http.StatusGone, sentinel.With("user").(errors.Error).Code)
+}
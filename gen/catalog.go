@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gildas/go-errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog describes the sentinels to generate
+type Catalog struct {
+	Package   string       `json:"package" yaml:"package"`
+	Sentinels []Definition `json:"sentinels" yaml:"sentinels"`
+}
+
+// Definition describes a sentinel to generate
+type Definition struct {
+	Name        string `json:"name" yaml:"name"`
+	Code        int    `json:"code" yaml:"code"`
+	ID          string `json:"id" yaml:"id"`
+	Text        string `json:"text" yaml:"text"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	DocURL      string `json:"doc_url,omitempty" yaml:"doc_url,omitempty"`
+	Severity    string `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Retryable   bool   `json:"retryable,omitempty" yaml:"retryable,omitempty"`
+}
+
+// LoadCatalog loads a Catalog from a YAML or JSON file
+//
+// The format is guessed from the file extension, YAML is the default.
+//
+// The Catalog is not validated.
+func LoadCatalog(path string) (*Catalog, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var catalog Catalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(payload, &catalog)
+	default:
+		err = yaml.Unmarshal(payload, &catalog)
+	}
+	if err != nil {
+		return nil, errors.JSONUnmarshalError.Wrap(err)
+	}
+	return &catalog, nil
+}
+
+// Validate checks that the Catalog can be generated
+func (catalog Catalog) Validate() error {
+	if len(catalog.Package) == 0 {
+		return errors.ArgumentMissing.With("package")
+	}
+	names := map[string]bool{}
+	ids := map[string]bool{}
+	for _, definition := range catalog.Sentinels {
+		if len(definition.Name) == 0 {
+			return errors.ArgumentMissing.With("name")
+		}
+		if names[definition.Name] {
+			return errors.DuplicateFound.With("name", definition.Name)
+		}
+		names[definition.Name] = true
+		if err := errors.ValidateID(definition.ID); err != nil {
+			return err
+		}
+		if ids[definition.ID] {
+			return errors.DuplicateFound.With("id", definition.ID)
+		}
+		ids[definition.ID] = true
+		if len(definition.Text) == 0 {
+			return errors.ArgumentMissing.With("text")
+		}
+		switch errors.Severity(definition.Severity) {
+		case "", errors.SeverityInfo, errors.SeverityWarning, errors.SeverityError, errors.SeverityCritical:
+		default:
+			return errors.ArgumentInvalid.With("severity", definition.Severity)
+		}
+	}
+	return nil
+}
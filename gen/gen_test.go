@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gildas/go-errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type GenSuite struct {
+	suite.Suite
+	Name string
+}
+
+func TestGenSuite(t *testing.T) {
+	suite.Run(t, new(GenSuite))
+}
+
+func (suite *GenSuite) SetupSuite() {
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+}
+
+func (suite *GenSuite) TestCanLoadYAMLCatalog() {
+	catalog, err := LoadCatalog("testdata/billing.yaml")
+	suite.Require().Nil(err)
+	suite.Require().Nil(catalog.Validate())
+	suite.Assert().Equal("billing", catalog.Package)
+	suite.Require().Len(catalog.Sentinels, 2)
+	suite.Assert().Equal("PaymentTimeout", catalog.Sentinels[1].Name)
+	suite.Assert().Equal("warning", catalog.Sentinels[1].Severity)
+	suite.Assert().True(catalog.Sentinels[1].Retryable)
+}
+
+func (suite *GenSuite) TestCanLoadJSONCatalog() {
+	catalog, err := LoadCatalog("testdata/billing.json")
+	suite.Require().Nil(err)
+	suite.Require().Nil(catalog.Validate())
+	suite.Require().Len(catalog.Sentinels, 1)
+	suite.Assert().Equal("error.invoice.notfound", catalog.Sentinels[0].ID)
+}
+
+func (suite *GenSuite) TestShouldFailLoadingMissingCatalog() {
+	_, err := LoadCatalog("testdata/missing.yaml")
+	suite.Assert().ErrorIs(err, os.ErrNotExist)
+}
+
+func (suite *GenSuite) TestShouldFailValidatingInvalidCatalog() {
+	suite.Assert().ErrorIs(Catalog{}.Validate(), errors.ArgumentMissing)
+	suite.Assert().ErrorIs(Catalog{Package: "billing", Sentinels: []Definition{{Name: "Bogus", ID: "Bogus", Text: "Bogus"}}}.Validate(), errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(Catalog{Package: "billing", Sentinels: []Definition{{Name: "Bogus", ID: "error.bogus", Text: "Bogus", Severity: "fatal"}}}.Validate(), errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(Catalog{Package: "billing", Sentinels: []Definition{
+		{Name: "Bogus", ID: "error.bogus", Text: "Bogus"},
+		{Name: "Bogus", ID: "error.bogus2", Text: "Bogus"},
+	}}.Validate(), errors.DuplicateFound)
+}
+
+func (suite *GenSuite) TestCanGenerateSentinels() {
+	catalog, err := LoadCatalog("testdata/billing.yaml")
+	suite.Require().Nil(err)
+
+	source, err := GenerateSentinels(*catalog)
+	suite.Require().Nil(err)
+	suite.Assert().Contains(string(source), "// Code generated by github.com/gildas/go-errors/gen. DO NOT EDIT.\n\npackage billing\n")
+	suite.Assert().Contains(string(source), "// InvoiceNotFound reports error error.invoice.notfound.\nvar InvoiceNotFound = errors.NewSentinel(404, \"error.invoice.notfound\", \"Invoice %s Not Found\", errors.DocURL(\"https://acme.com/errors/invoice-not-found\"))\n")
+	suite.Assert().Contains(string(source), "// PaymentTimeout is used when the payment provider does not answer in time.\nvar PaymentTimeout = errors.NewSentinel(504, \"error.payment.timeout\", \"Payment %s timed out\", errors.SeverityLevel(errors.SeverityWarning), errors.Retryable())\n")
+}
+
+func (suite *GenSuite) TestCanGenerateTests() {
+	catalog, err := LoadCatalog("testdata/billing.yaml")
+	suite.Require().Nil(err)
+
+	source, err := GenerateTests(*catalog)
+	suite.Require().Nil(err)
+	suite.Assert().Contains(string(source), "func TestInvoiceNotFound(t *testing.T) {")
+	suite.Assert().Contains(string(source), "func TestPaymentTimeout(t *testing.T) {")
+}
+
+func (suite *GenSuite) TestCanGenerateMessages() {
+	catalog, err := LoadCatalog("testdata/billing.yaml")
+	suite.Require().Nil(err)
+
+	payload, err := GenerateMessages(*catalog)
+	suite.Require().Nil(err)
+	suite.Assert().JSONEq(`{"error.invoice.notfound": "Invoice %s Not Found", "error.payment.timeout": "Payment %s timed out"}`, string(payload))
+}
+
+func (suite *GenSuite) TestCanRun() {
+	folder := suite.T().TempDir()
+	output := filepath.Join(folder, "errors")
+	suite.Require().Nil(run("testdata/billing.yaml", output, "invoices", "fr"))
+
+	source, err := os.ReadFile(output + ".go")
+	suite.Require().Nil(err)
+	suite.Assert().Contains(string(source), "package invoices\n")
+	suite.Assert().FileExists(output + "_test.go")
+	suite.Assert().FileExists(output + ".fr.json")
+
+	suite.Assert().ErrorIs(run("", output, "", "en"), errors.ArgumentMissing)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/gildas/go-errors"
+)
+
+var sentinelsTemplate = template.Must(template.New("sentinels").Funcs(template.FuncMap{"severity": severityConstant}).Parse(`// Code generated by github.com/gildas/go-errors/gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import "github.com/gildas/go-errors"
+{{ range .Sentinels }}
+// {{ .Name }} {{ if .Description }}{{ .Description }}{{ else }}reports error {{ .ID }}.{{ end }}
+var {{ .Name }} = errors.NewSentinel({{ .Code }}, {{ printf "%q" .ID }}, {{ printf "%q" .Text }}
+{{- if .DocURL }}, errors.DocURL({{ printf "%q" .DocURL }}){{ end }}
+{{- if .Severity }}, errors.SeverityLevel({{ severity .Severity }}){{ end }}
+{{- if .Retryable }}, errors.Retryable(){{ end }})
+{{ end }}`))
+
+var testsTemplate = template.Must(template.New("tests").Parse(`// Code generated by github.com/gildas/go-errors/gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"testing"
+
+	"github.com/gildas/go-errors"
+)
+{{ range .Sentinels }}
+func Test{{ .Name }}(t *testing.T) {
+	err := {{ .Name }}.WithStack()
+	if !errors.Is(err, {{ .Name }}) {
+		t.Errorf("err should match {{ .Name }}")
+	}
+	if sentinel, found := errors.Lookup({{ printf "%q" .ID }}); !found || sentinel.Code != {{ .Code }} {
+		t.Errorf("{{ .Name }} should be registered with code {{ .Code }}")
+	}
+}
+{{ end }}`))
+
+// GenerateSentinels generates the Go source code of the sentinels
+func GenerateSentinels(catalog Catalog) ([]byte, error) {
+	return generate(sentinelsTemplate, catalog)
+}
+
+// GenerateTests generates the Go source code of the tests of the sentinels
+func GenerateTests(catalog Catalog) ([]byte, error) {
+	return generate(testsTemplate, catalog)
+}
+
+// GenerateMessages generates the localization stub of the sentinels
+//
+// The stub is a JSON object that maps the sentinel IDs to their text.
+func GenerateMessages(catalog Catalog) ([]byte, error) {
+	messages := make(map[string]string, len(catalog.Sentinels))
+	for _, definition := range catalog.Sentinels {
+		messages[definition.ID] = definition.Text
+	}
+	payload, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return nil, errors.JSONMarshalError.Wrap(err)
+	}
+	return append(payload, '\n'), nil
+}
+
+// generate executes the given template and formats the result as Go source code
+func generate(tmpl *template.Template, catalog Catalog) ([]byte, error) {
+	var source bytes.Buffer
+	if err := tmpl.Execute(&source, catalog); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return formatted, nil
+}
+
+// severityConstant gives the Go constant of the given severity
+func severityConstant(severity string) string {
+	return "errors.Severity" + strings.ToUpper(severity[:1]) + severity[1:]
+}
@@ -0,0 +1,92 @@
+/*
+Gen generates sentinels from a declarative catalog of error definitions.
+
+The catalog is a YAML or JSON file:
+
+	package: billing
+	sentinels:
+	  - name: InvoiceNotFound
+	    code: 404
+	    id: error.invoice.notfound
+	    text: "Invoice %s Not Found"
+	    doc_url: https://acme.com/errors/invoice-not-found
+	  - name: PaymentTimeout
+	    code: 504
+	    id: error.payment.timeout
+	    text: "Payment %s timed out"
+	    severity: warning
+	    retryable: true
+
+Gen writes the sentinels in <output>.go, their tests in <output>_test.go,
+and a localization stub in <output>.<lang>.json.
+
+Usage with go generate:
+
+	//go:generate go run github.com/gildas/go-errors/gen -input errors.yaml
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func main() {
+	input := flag.String("input", "", "the catalog of error definitions (YAML or JSON)")
+	output := flag.String("output", "", "the base name of the generated files (default: the input without its extension)")
+	pkg := flag.String("package", "", "the package of the generated files (default: the catalog package)")
+	lang := flag.String("lang", "en", "the language of the localization stub")
+	flag.Parse()
+
+	if err := run(*input, *output, *pkg, *lang); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output, pkg, lang string) error {
+	if len(input) == 0 {
+		return errors.ArgumentMissing.With("input")
+	}
+	catalog, err := LoadCatalog(input)
+	if err != nil {
+		return err
+	}
+	if len(pkg) > 0 {
+		catalog.Package = pkg
+	}
+	if err = catalog.Validate(); err != nil {
+		return err
+	}
+	if len(output) == 0 {
+		output = strings.TrimSuffix(input, filepath.Ext(input))
+	}
+
+	sentinels, err := GenerateSentinels(*catalog)
+	if err != nil {
+		return err
+	}
+	tests, err := GenerateTests(*catalog)
+	if err != nil {
+		return err
+	}
+	messages, err := GenerateMessages(*catalog)
+	if err != nil {
+		return err
+	}
+	for filename, content := range map[string][]byte{
+		output + ".go":                sentinels,
+		output + "_test.go":           tests,
+		output + "." + lang + ".json": messages,
+	} {
+		if err := os.WriteFile(filename, content, 0o644); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
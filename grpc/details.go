@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"strings"
+
+	"github.com/gildas/go-errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// QuotaViolation describes a quota that was exceeded, it is the Go form of errdetails.QuotaFailure_Violation used in the attributes of errors
+type QuotaViolation struct {
+	// Subject is what exceeded the quota, like: "project:acme"
+	Subject string `json:"subject,omitempty"`
+	// Description tells how the quota was exceeded
+	Description string `json:"description,omitempty"`
+}
+
+// argumentSentinels are the sentinels that describe an invalid argument, their What is the field
+var argumentSentinels = []errors.Error{
+	errors.ArgumentMissing,
+	errors.ArgumentInvalid,
+	errors.ArgumentExpected,
+	errors.ArgumentOutOfRange,
+}
+
+// quotaSentinels are the sentinels that describe an exceeded quota, their What is the subject
+var quotaSentinels = []errors.Error{
+	errors.QuotaExceeded,
+	errors.RateLimitExceeded,
+	errors.ConcurrencyLimitExceeded,
+}
+
+// detailsOf gives the BadRequest, RetryInfo, and QuotaFailure detail messages of the given error, final is its first errors.Error
+//
+// The descriptions of the violations are the messages of the sanitized errors.
+func detailsOf(err error, final errors.Error) (details []protoadapt.MessageV1) {
+	badRequest := &errdetails.BadRequest{}
+	quotaFailure := &errdetails.QuotaFailure{}
+	errors.Walk(err, func(cause error) bool {
+		switch actual := cause.(type) {
+		case errors.FieldError:
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: actual.Field, Description: actual.Error()})
+		case errors.Error:
+			if len(actual.What) == 0 {
+				break
+			}
+			for _, sentinel := range argumentSentinels {
+				if actual.ID == sentinel.ID {
+					badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: actual.What, Description: errors.Sanitize(actual).Error()})
+				}
+			}
+			for _, sentinel := range quotaSentinels {
+				if actual.ID == sentinel.ID {
+					quotaFailure.Violations = append(quotaFailure.Violations, &errdetails.QuotaFailure_Violation{Subject: actual.What, Description: errors.Sanitize(actual).Error()})
+				}
+			}
+		}
+		return true
+	})
+	if len(badRequest.FieldViolations) > 0 {
+		details = append(details, badRequest)
+	}
+	if final.RateLimit != nil {
+		if delay := final.RateLimit.RetryAfter(); delay > 0 {
+			details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+		}
+	}
+	if len(quotaFailure.Violations) > 0 {
+		details = append(details, quotaFailure)
+	}
+	return
+}
+
+// applyDetail applies the given detail message of a status to the given Error, see FromStatus
+func applyDetail(final *errors.Error, detail interface{}) {
+	switch actual := detail.(type) {
+	case *errdetails.ErrorInfo:
+		if len(actual.Reason) > 0 {
+			final.ID = actual.Reason
+		}
+		if len(actual.Domain) > 0 {
+			setAttribute(final, "domain", actual.Domain)
+		}
+	case *errdetails.BadRequest:
+		if len(actual.FieldViolations) == 0 {
+			return
+		}
+		causes := &errors.ValidationErrors{}
+		for _, violation := range actual.FieldViolations {
+			fieldError := errors.ValidationFailed.ForField(violation.Field, "", nil)
+			if len(violation.Description) > 0 {
+				fieldError.Message = violation.Description
+			}
+			causes.Append(fieldError)
+		}
+		final.Cause = causes
+	case *errdetails.RetryInfo:
+		final.Retryable = true
+		if delay := actual.RetryDelay.AsDuration(); delay > 0 {
+			setAttribute(final, "retry_after", delay)
+		}
+	case *errdetails.QuotaFailure:
+		if len(actual.Violations) == 0 {
+			return
+		}
+		if strings.HasPrefix(final.ID, "error.grpc.") {
+			final.ID = errors.QuotaExceeded.ID
+		}
+		violations := make([]QuotaViolation, 0, len(actual.Violations))
+		for _, violation := range actual.Violations {
+			violations = append(violations, QuotaViolation{Subject: violation.Subject, Description: violation.Description})
+		}
+		setAttribute(final, "quota_violations", violations)
+	}
+}
+
+// setAttribute sets an attribute of the given Error
+func setAttribute(final *errors.Error, key string, value interface{}) {
+	if final.Attributes == nil {
+		final.Attributes = map[string]interface{}{}
+	}
+	final.Attributes[key] = value
+}
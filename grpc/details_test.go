@@ -0,0 +1,87 @@
+package grpc_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-errors/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// findDetail finds the detail message of the given type in the given status
+func findDetail[Detail any](st *status.Status) (detail Detail, found bool) {
+	for _, item := range st.Details() {
+		if detail, found = item.(Detail); found {
+			return
+		}
+	}
+	return
+}
+
+func (suite *GRPCSuite) TestCanConvertValidationErrorsToBadRequest() {
+	errs := &errors.ValidationErrors{}
+	errs.Add("user.email", "format", "john")
+	errs.Append(errors.ArgumentMissing.With("user.name"))
+	st := grpc.ToStatus(errs.AsError())
+	suite.Assert().Equal(codes.InvalidArgument, st.Code())
+
+	badRequest, found := findDetail[*errdetails.BadRequest](st)
+	suite.Require().True(found, "the status should have a BadRequest")
+	suite.Require().Len(badRequest.FieldViolations, 2)
+	suite.Assert().Equal("user.email", badRequest.FieldViolations[0].Field)
+	suite.Assert().Equal("user.name", badRequest.FieldViolations[1].Field)
+	suite.Assert().Equal("Argument user.name is missing", badRequest.FieldViolations[1].Description)
+}
+
+func (suite *GRPCSuite) TestCanConvertRateLimitToRetryInfoAndQuotaFailure() {
+	st := grpc.ToStatus(errors.RateLimitExceeded.WithWhat("/api/users").WithLimit(100, 0, time.Now().Add(30*time.Second)).WithStack())
+	suite.Assert().Equal(codes.ResourceExhausted, st.Code())
+
+	retryInfo, found := findDetail[*errdetails.RetryInfo](st)
+	suite.Require().True(found, "the status should have a RetryInfo")
+	suite.Assert().InDelta(30*time.Second, retryInfo.RetryDelay.AsDuration(), float64(time.Second))
+
+	quotaFailure, found := findDetail[*errdetails.QuotaFailure](st)
+	suite.Require().True(found, "the status should have a QuotaFailure")
+	suite.Require().Len(quotaFailure.Violations, 1)
+	suite.Assert().Equal("/api/users", quotaFailure.Violations[0].Subject)
+}
+
+func (suite *GRPCSuite) TestCanParseStandardDetails() {
+	st, err := status.New(codes.ResourceExhausted, "Quota exceeded").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(2500 * time.Millisecond)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{Subject: "project:acme", Description: "Daily limit reached"}}},
+	)
+	suite.Require().NoError(err)
+	err = grpc.FromStatus(st)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.QuotaExceeded)
+	suite.Assert().True(errors.IsCode(err, http.StatusTooManyRequests))
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().True(details.Retryable)
+	suite.Assert().Equal(2500*time.Millisecond, details.Attributes["retry_after"])
+	suite.Assert().Equal([]grpc.QuotaViolation{{Subject: "project:acme", Description: "Daily limit reached"}}, details.Attributes["quota_violations"])
+
+	st, err = status.New(codes.InvalidArgument, "Invalid request").WithDetails(
+		&errdetails.ErrorInfo{Reason: "error.user.invalid", Domain: "acme.com"},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "user.email", Description: "Invalid email"}}},
+	)
+	suite.Require().NoError(err)
+	err = grpc.FromGRPCStatus(st.Err())
+	suite.Require().Error(err)
+	suite.Assert().True(errors.Match(err, "error.user.invalid"))
+	suite.Assert().ErrorIs(err, errors.ValidationFailed)
+	var validation *errors.ValidationErrors
+	suite.Require().ErrorAs(err, &validation)
+	suite.Require().Len(validation.FieldErrors(), 1)
+	suite.Assert().Equal("user.email", validation.FieldErrors()[0].Field)
+	suite.Assert().Equal("Invalid email", validation.FieldErrors()[0].Message)
+	details, ok = err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("acme.com", details.Attributes["domain"])
+}
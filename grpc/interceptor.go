@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// StatusError is the error returned by the server interceptors, it carries the *status.Status of the original error
+//
+// The gRPC server sends that status to the client, see status.FromError.
+type StatusError struct {
+	// Status is the status of the original error, see ToStatus
+	Status *status.Status
+	// Cause is the original error, it is not sent to the client
+	Cause error
+}
+
+// Error returns the string version of this error
+//
+// implements error interface
+func (e *StatusError) Error() string {
+	return e.Status.Message()
+}
+
+// Unwrap gives the original error
+//
+// implements errors.Unwrap interface (package "errors").
+func (e *StatusError) Unwrap() error {
+	return e.Cause
+}
+
+// GRPCStatus gives the status of this error
+//
+// implements the interface that status.FromError looks for
+func (e *StatusError) GRPCStatus() *status.Status {
+	return e.Status
+}
+
+// ServerError converts the given error into a *StatusError, see ToStatus
+//
+// If err is nil, ServerError returns nil.
+func ServerError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*StatusError); ok {
+		return err
+	}
+	return &StatusError{Status: ToStatus(err), Cause: err}
+}
+
+// ClientError converts the gRPC error of err's chain into an error, see FromGRPCStatus
+//
+// If err is nil, ClientError returns nil.
+func ClientError(err error) error {
+	return FromGRPCStatus(err)
+}
+
+// UnaryServerInterceptor is a grpc.UnaryServerInterceptor that converts the errors of the handlers with ServerError
+//
+// Example:
+//
+//	server := grpc.NewServer(grpc.UnaryInterceptor(grpcerrors.UnaryServerInterceptor))
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, ServerError(err)
+}
+
+// StreamServerInterceptor is a grpc.StreamServerInterceptor that converts the errors of the handlers with ServerError
+//
+// Example:
+//
+//	server := grpc.NewServer(grpc.StreamInterceptor(grpcerrors.StreamServerInterceptor))
+func StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return ServerError(handler(srv, stream))
+}
+
+// UnaryClientInterceptor is a grpc.UnaryClientInterceptor that converts the gRPC errors of the calls with ClientError
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target, grpc.WithUnaryInterceptor(grpcerrors.UnaryClientInterceptor))
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return ClientError(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// StreamClientInterceptor is a grpc.StreamClientInterceptor that converts the gRPC errors of the streams with ClientError
+//
+// The errors returned by the SendMsg and RecvMsg methods of the stream are converted as well, io.EOF is returned as is.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target, grpc.WithStreamInterceptor(grpcerrors.StreamClientInterceptor))
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, ClientError(err)
+	}
+	return &clientStream{ClientStream: stream}, nil
+}
+
+// clientStream converts the errors of a grpc.ClientStream with ClientError
+type clientStream struct {
+	grpc.ClientStream
+}
+
+// SendMsg sends a message on the stream
+func (stream *clientStream) SendMsg(m any) error {
+	return ClientError(stream.ClientStream.SendMsg(m))
+}
+
+// RecvMsg receives a message from the stream
+func (stream *clientStream) RecvMsg(m any) error {
+	return ClientError(stream.ClientStream.RecvMsg(m))
+}
@@ -0,0 +1,96 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+
+	"github.com/gildas/go-errors"
+	grpcerrors "github.com/gildas/go-errors/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer fails all its calls with the given error
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	err error
+}
+
+func (server *healthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, server.err
+}
+
+func (server *healthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
+	return server.err
+}
+
+// serve starts a gRPC server that fails all its calls with the given error, and returns a client connected to it
+func (suite *GRPCSuite) serve(err error, clientOptions ...grpc.DialOption) grpc_health_v1.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcerrors.UnaryServerInterceptor),
+		grpc.StreamInterceptor(grpcerrors.StreamServerInterceptor),
+	)
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{err: err})
+	go func() { _ = server.Serve(listener) }()
+	suite.T().Cleanup(server.Stop)
+
+	clientOptions = append(clientOptions,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, cerr := grpc.NewClient("passthrough:///bufnet", clientOptions...)
+	suite.Require().NoError(cerr)
+	suite.T().Cleanup(func() { _ = conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func (suite *GRPCSuite) TestCanInterceptUnaryServerCalls() {
+	client := suite.serve(errors.NotFound.With("user", "john"))
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	suite.Require().Error(err)
+	suite.Assert().Equal(codes.NotFound, status.Code(err))
+	suite.Assert().Equal("user john Not Found", status.Convert(err).Message())
+
+	suite.Assert().ErrorIs(grpcerrors.ClientError(err), errors.NotFound)
+}
+
+func (suite *GRPCSuite) TestCanInterceptStreamServerCalls() {
+	client := suite.serve(errors.Timeout.With("stream"))
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	suite.Require().NoError(err)
+	_, err = stream.Recv()
+	suite.Require().Error(err)
+	suite.Assert().Equal(codes.DeadlineExceeded, status.Code(err))
+}
+
+func (suite *GRPCSuite) TestCanInterceptUnaryClientCalls() {
+	client := suite.serve(errors.ArgumentMissing.With("name"), grpc.WithUnaryInterceptor(grpcerrors.UnaryClientInterceptor))
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("name", details.What)
+}
+
+func (suite *GRPCSuite) TestCanInterceptStreamClientCalls() {
+	client := suite.serve(errors.Timeout.With("stream"), grpc.WithStreamInterceptor(grpcerrors.StreamClientInterceptor))
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	suite.Require().NoError(err)
+	_, err = stream.Recv()
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.Timeout)
+}
+
+func (suite *GRPCSuite) TestCanConvertServerError() {
+	suite.Assert().Nil(grpcerrors.ServerError(nil))
+	err := grpcerrors.ServerError(errors.NotFound.With("user", "john"))
+	suite.Assert().Same(err, grpcerrors.ServerError(err), "ServerError should not wrap a StatusError again")
+	suite.Assert().ErrorIs(err, errors.NotFound, "the original error should be kept on the server side")
+	suite.Assert().Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,214 @@
+// Package grpc converts errors of github.com/gildas/go-errors from and to gRPC statuses.
+//
+// This package is a separate module, so the main module does not depend on google.golang.org/grpc.
+//
+// The errors are sanitized before they cross the wire, see errors.Sanitize, so the remote clients do not get their causes,
+// their attributes, or the host and process that produced them.
+//
+// Example:
+//
+//	server := grpc.NewServer(
+//	  grpc.UnaryInterceptor(grpcerrors.UnaryServerInterceptor),
+//	  grpc.StreamInterceptor(grpcerrors.StreamServerInterceptor),
+//	)
+package grpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gildas/go-errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is the domain of the errdetails.ErrorInfo built by ToStatus
+const Domain = "github.com/gildas/go-errors"
+
+// grpcCodes maps HTTP status codes to gRPC status codes
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:                   codes.InvalidArgument,
+	http.StatusUnauthorized:                 codes.Unauthenticated,
+	http.StatusForbidden:                    codes.PermissionDenied,
+	http.StatusNotFound:                     codes.NotFound,
+	http.StatusRequestTimeout:               codes.DeadlineExceeded,
+	http.StatusConflict:                     codes.Aborted,
+	http.StatusPreconditionFailed:           codes.FailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: codes.OutOfRange,
+	http.StatusUnprocessableEntity:          codes.InvalidArgument,
+	http.StatusTooManyRequests:              codes.ResourceExhausted,
+	499:                                     codes.Canceled, // Client Closed Request
+	http.StatusInternalServerError:          codes.Internal,
+	http.StatusNotImplemented:               codes.Unimplemented,
+	http.StatusBadGateway:                   codes.Unavailable,
+	http.StatusServiceUnavailable:           codes.Unavailable,
+	http.StatusGatewayTimeout:               codes.DeadlineExceeded,
+}
+
+// httpCodes maps gRPC status codes to HTTP status codes
+var httpCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499, // Client Closed Request
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// codesLock protects grpcCodes and httpCodes
+var codesLock sync.RWMutex
+
+// MapHTTPToGRPC tells CodeOf, and therefore ToStatus, to translate the given HTTP status code into the given gRPC status code
+//
+// If the HTTP status code was already mapped, its mapping is replaced.
+//
+// Example:
+//
+//	grpcerrors.MapHTTPToGRPC(http.StatusConflict, codes.AlreadyExists)
+func MapHTTPToGRPC(httpCode int, code codes.Code) {
+	codesLock.Lock()
+	defer codesLock.Unlock()
+	grpcCodes[httpCode] = code
+}
+
+// MapGRPCToHTTP tells HTTPCodeOf, and therefore FromStatus, to translate the given gRPC status code into the given HTTP status code
+//
+// If the gRPC status code was already mapped, its mapping is replaced.
+func MapGRPCToHTTP(code codes.Code, httpCode int) {
+	codesLock.Lock()
+	defer codesLock.Unlock()
+	httpCodes[code] = httpCode
+}
+
+// CodeOf gives the gRPC status code of the given HTTP status code, see MapHTTPToGRPC
+//
+// HTTP codes that have no gRPC equivalent give InvalidArgument for 4xx, Internal for 5xx, and Unknown for the others.
+func CodeOf(httpCode int) codes.Code {
+	codesLock.RLock()
+	code, found := grpcCodes[httpCode]
+	codesLock.RUnlock()
+	if found {
+		return code
+	}
+	switch {
+	case httpCode >= 200 && httpCode < 300:
+		return codes.OK
+	case httpCode >= 400 && httpCode < 500:
+		return codes.InvalidArgument
+	case httpCode >= 500:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// HTTPCodeOf gives the HTTP status code of the given gRPC status code, see MapGRPCToHTTP
+func HTTPCodeOf(code codes.Code) int {
+	codesLock.RLock()
+	httpCode, found := httpCodes[code]
+	codesLock.RUnlock()
+	if found {
+		return httpCode
+	}
+	return http.StatusInternalServerError
+}
+
+// ToStatus converts the given error into a *status.Status
+//
+// The first errors.Error of err's chain is sanitized (see errors.Sanitize), it gives the code and the message of the status.
+// Errors that are not an errors.Error give an Unknown status with a generic message.
+//
+// The status carries an errdetails.ErrorInfo with the ID of the error as its reason and the JSON form of the sanitized error
+// in its "error" metadata, so FromStatus can rebuild the error on the other side of the RPC.
+//
+// The status also carries the standard detail messages that apply to the error, so clients that do not use this package get them:
+//
+//	errdetails.BadRequest   with the errors.FieldError of the chain and its argument errors, like errors.ArgumentMissing
+//	errdetails.RetryInfo    with the time to wait of the errors.RateLimit of the error
+//	errdetails.QuotaFailure with the quota errors, like errors.QuotaExceeded
+//
+// If err is nil, ToStatus returns an OK status.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	code := codes.Unknown
+	var final errors.Error
+	if errors.As(err, &final) {
+		code = CodeOf(final.Code)
+		err = final
+	}
+	sanitized := errors.Sanitize(err).(errors.Error)
+	info := &errdetails.ErrorInfo{Reason: sanitized.ID, Domain: Domain}
+	if payload, jerr := json.Marshal(sanitized); jerr == nil {
+		info.Metadata = map[string]string{"error": string(payload)}
+	}
+	result := status.New(code, sanitized.Error())
+	details := append([]protoadapt.MessageV1{info}, detailsOf(err, final)...)
+	if detailed, derr := result.WithDetails(details...); derr == nil {
+		result = detailed
+	}
+	return result
+}
+
+// FromStatus converts the given *status.Status into an error
+//
+// If the status carries the errdetails.ErrorInfo of ToStatus, the sanitized error is rebuilt.
+// Otherwise, the error has the HTTP Code of the gRPC status code, an ID like "error.grpc.notfound",
+// and the message of the status as its Text. The standard detail messages of the status are parsed as well:
+//
+//	errdetails.ErrorInfo    its reason becomes the ID of the error and its domain goes to the "domain" attribute
+//	errdetails.BadRequest   the field violations become errors.FieldError in an errors.ValidationErrors cause
+//	errdetails.RetryInfo    the error is Retryable and its "retry_after" attribute contains the time to wait
+//	errdetails.QuotaFailure the error is an errors.QuotaExceeded, unless it has a reason, and its "quota_violations" attribute contains the violations
+//
+// If the status is nil or OK, FromStatus returns nil.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			var original errors.Error
+			if payload, found := info.Metadata["error"]; found && json.Unmarshal([]byte(payload), &original) == nil {
+				return original
+			}
+		}
+	}
+	final := errors.Error{
+		Code: HTTPCodeOf(st.Code()),
+		ID:   "error.grpc." + strings.ToLower(st.Code().String()),
+		Text: strings.ReplaceAll(st.Message(), "%", "%%"),
+	}
+	for _, detail := range st.Details() {
+		applyDetail(&final, detail)
+	}
+	return final.WithStack()
+}
+
+// FromGRPCStatus converts the gRPC status error of err's chain, like the ones of google.golang.org/grpc/status, into an error
+//
+// The gRPC errors are recognized by their GRPCStatus() method, see status.FromError.
+//
+// If there is no gRPC error in err's chain, err is returned unchanged.
+func FromGRPCStatus(err error) error {
+	var grpcError interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcError) {
+		return err
+	}
+	return FromStatus(grpcError.GRPCStatus())
+}
@@ -0,0 +1,106 @@
+package grpc_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-errors/grpc"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCSuite struct {
+	suite.Suite
+}
+
+func TestGRPCSuite(t *testing.T) {
+	suite.Run(t, new(GRPCSuite))
+}
+
+func (suite *GRPCSuite) TestCanConvertErrorToStatus() {
+	st := grpc.ToStatus(errors.NotFound.With("user", "john"))
+	suite.Assert().Equal(codes.NotFound, st.Code())
+	suite.Assert().Equal("user john Not Found", st.Message())
+	suite.Require().Len(st.Details(), 1)
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	suite.Require().True(ok, "the detail should be an ErrorInfo")
+	suite.Assert().Equal("error.notfound", info.Reason)
+	suite.Assert().Equal(grpc.Domain, info.Domain)
+	suite.Assert().Contains(info.Metadata, "error")
+
+	suite.Assert().Equal(codes.OK, grpc.ToStatus(nil).Code())
+	suite.Assert().Equal(codes.NotFound, grpc.ToStatus(fmt.Errorf("lookup failed: %w", errors.NotFound.With("user", "john"))).Code())
+}
+
+func (suite *GRPCSuite) TestCanSanitizeStatus() {
+	cause := fmt.Errorf("dial tcp 10.0.0.12:5432: connection refused")
+	err := errors.HTTPInternalServerError.Wrap(cause).(errors.Error).WithField("dsn", "postgres://admin:secret@db/prod")
+	st := grpc.ToStatus(err)
+	suite.Assert().Equal(codes.Internal, st.Code())
+	suite.Assert().Equal("Internal Server Error", st.Message())
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	suite.Assert().NotContains(info.Metadata["error"], "10.0.0.12")
+	suite.Assert().NotContains(info.Metadata["error"], "secret")
+	suite.Assert().NotContains(info.Metadata["error"], "origin", "the service info should not be sent")
+
+	st = grpc.ToStatus(cause)
+	suite.Assert().Equal(codes.Unknown, st.Code())
+	suite.Assert().NotContains(st.Message(), "10.0.0.12")
+}
+
+func (suite *GRPCSuite) TestCanConvertStatusToError() {
+	err := grpc.FromStatus(grpc.ToStatus(errors.ArgumentInvalid.With("name", "john")))
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("name", details.What)
+	suite.Assert().Equal("john", details.Value)
+
+	err = grpc.FromStatus(status.New(codes.Unavailable, "backend is down at 100%"))
+	suite.Require().Error(err)
+	suite.Assert().True(errors.IsCode(err, http.StatusServiceUnavailable))
+	suite.Assert().Equal("backend is down at 100%", err.Error())
+	suite.Assert().True(errors.Match(err, "error.grpc.unavailable"))
+
+	suite.Assert().Nil(grpc.FromStatus(status.New(codes.OK, "")))
+	suite.Assert().Nil(grpc.FromStatus(nil))
+}
+
+func (suite *GRPCSuite) TestCanConvertGRPCStatusError() {
+	err := grpc.FromGRPCStatus(fmt.Errorf("call failed: %w", grpc.ToStatus(errors.ArgumentMissing.With("name")).Err()))
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+
+	err = grpc.FromGRPCStatus(status.Error(codes.PermissionDenied, "Access denied"))
+	suite.Assert().True(errors.IsCode(err, http.StatusForbidden))
+	suite.Assert().True(errors.Match(err, "error.grpc.permissiondenied"))
+
+	simple := fmt.Errorf("simple error")
+	suite.Assert().Same(simple, grpc.FromGRPCStatus(simple), "errors without a gRPC status should be returned as is")
+}
+
+func (suite *GRPCSuite) TestCanMapCodes() {
+	suite.Assert().Equal(codes.Unauthenticated, grpc.CodeOf(http.StatusUnauthorized))
+	suite.Assert().Equal(codes.InvalidArgument, grpc.CodeOf(http.StatusTeapot))
+	suite.Assert().Equal(codes.Internal, grpc.CodeOf(http.StatusHTTPVersionNotSupported))
+	suite.Assert().Equal(http.StatusConflict, grpc.HTTPCodeOf(codes.AlreadyExists))
+	suite.Assert().Equal(http.StatusInternalServerError, grpc.HTTPCodeOf(codes.Code(42)))
+}
+
+func (suite *GRPCSuite) TestCanCustomizeCodeMapping() {
+	defer grpc.MapHTTPToGRPC(http.StatusConflict, grpc.CodeOf(http.StatusConflict))
+	defer grpc.MapGRPCToHTTP(codes.FailedPrecondition, grpc.HTTPCodeOf(codes.FailedPrecondition))
+
+	grpc.MapHTTPToGRPC(http.StatusConflict, codes.AlreadyExists)
+	suite.Assert().Equal(codes.AlreadyExists, grpc.CodeOf(http.StatusConflict))
+	suite.Assert().Equal(codes.AlreadyExists, grpc.ToStatus(errors.Conflict.With("user")).Code())
+
+	grpc.MapGRPCToHTTP(codes.FailedPrecondition, http.StatusPreconditionFailed)
+	suite.Assert().Equal(http.StatusPreconditionFailed, grpc.HTTPCodeOf(codes.FailedPrecondition))
+	suite.Assert().True(errors.IsCode(grpc.FromStatus(status.New(codes.FailedPrecondition, "Not ready")), http.StatusPreconditionFailed))
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const htmlStyle = `body{font-family:sans-serif;margin:2em;color:#222}
+details{margin:.5em 0 .5em 1em;padding-left:.5em;border-left:3px solid #c33}
+details.stack{border-left-color:#999}
+summary{cursor:pointer}
+.code{background:#c33;color:#fff;padding:0 .4em;border-radius:3px}
+.id{color:#066;font-family:monospace}
+.message{font-weight:bold}
+table{border-collapse:collapse;margin:.5em 0}
+th,td{border:1px solid #ccc;padding:.2em .5em;text-align:left;font-family:monospace}
+li.standard{color:#999}
+li code{font-weight:bold}
+pre.source{margin:.2em 0;padding:.2em .5em;background:#f4f4f4}`
+
+// ToHTML returns an HTML page showing err and every error in its chain, typically for development-mode error pages
+//
+// Each error is a collapsible section with its code, ID, message, hint, documentation link, attributes,
+// and stack trace. The causes and MultiError members are nested in their error's section.
+//
+// As the page shows the internals of err, it should not be sent to end users, see Sanitize.
+//
+// If err is nil, ToHTML returns an empty string.
+func ToHTML(err error) string {
+	if err == nil {
+		return ""
+	}
+	var sb strings.Builder
+
+	_, _ = sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	_, _ = sb.WriteString(html.EscapeString(strings.SplitN(err.Error(), "\n", 2)[0]))
+	_, _ = sb.WriteString("</title>\n<style>\n")
+	_, _ = sb.WriteString(htmlStyle)
+	_, _ = sb.WriteString("\n</style>\n</head>\n<body>\n")
+	if value, ok := asError(err); ok {
+		err = value.bounded()
+	}
+	writeHTML(&sb, err, nil)
+	_, _ = sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// ToHTML returns an HTML page showing this Error and every error in its chain.
+//
+// See ToHTML.
+func (e Error) ToHTML() string {
+	return ToHTML(e)
+}
+
+// writeHTML writes the section of err and its branches in the given builder
+//
+// path contains the pointer errors that lead to err.
+func writeHTML(sb *strings.Builder, err error, path []error) {
+	if isPointer(err) {
+		if slices.Contains(path, err) {
+			err = CauseCycle
+		} else {
+			path = append(path, err)
+		}
+	}
+	_, _ = sb.WriteString("<details open>\n<summary>")
+	if multi, ok := err.(*MultiError); ok {
+		count := 0
+		if multi != nil {
+			count = len(multi.Errors)
+		}
+		_, _ = sb.WriteString("<span class=\"message\">")
+		_, _ = sb.WriteString(strconv.Itoa(count))
+		_, _ = sb.WriteString(" errors</span></summary>\n")
+	} else if value, ok := asError(err); ok {
+		writeHTMLDetails(sb, value)
+	} else {
+		_, _ = fmt.Fprintf(sb, "<span class=\"id\">%s</span> <span class=\"message\">%s</span></summary>\n", html.EscapeString(fmt.Sprintf("%T", err)), html.EscapeString(err.Error()))
+	}
+	for _, branch := range branches(err) {
+		writeHTML(sb, branch, path)
+	}
+	_, _ = sb.WriteString("</details>\n")
+}
+
+// writeHTMLDetails writes the summary and the details of the given Error in the given builder
+func writeHTMLDetails(sb *strings.Builder, e Error) {
+	if e.Code != 0 {
+		_, _ = fmt.Fprintf(sb, "<span class=\"code\">%d</span> ", e.Code)
+	}
+	if len(e.ID) > 0 {
+		_, _ = fmt.Fprintf(sb, "<span class=\"id\">%s</span> ", html.EscapeString(e.ID))
+	}
+	_, _ = fmt.Fprintf(sb, "<span class=\"message\">%s</span></summary>\n", html.EscapeString(e.message()))
+	if len(e.Hint) > 0 {
+		_, _ = fmt.Fprintf(sb, "<p class=\"hint\">Hint: %s</p>\n", html.EscapeString(e.Hint))
+	}
+	if len(e.DocURL) > 0 {
+		url := html.EscapeString(e.DocURL)
+		_, _ = fmt.Fprintf(sb, "<p class=\"doc\">see: <a href=\"%s\">%s</a></p>\n", url, url)
+	}
+	fields := e.Fields()
+	delete(fields, "id")
+	delete(fields, "code")
+	delete(fields, "message")
+	if len(e.Hint) > 0 {
+		delete(fields, "hint")
+	}
+	if len(e.DocURL) > 0 {
+		delete(fields, "doc_url")
+	}
+	if len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		_, _ = sb.WriteString("<table class=\"attributes\">\n")
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(sb, "<tr><th>%s</th><td>%s</td></tr>\n", html.EscapeString(key), html.EscapeString(fmt.Sprintf("%+v", fields[key])))
+		}
+		_, _ = sb.WriteString("</table>\n")
+	}
+	if stack := e.Stack.visible(); len(stack) > 0 {
+		_, _ = sb.WriteString("<details class=\"stack\">\n<summary>Stack trace</summary>\n<ol>\n")
+		sources := int(sourceLines.Load())
+		for _, frame := range stack {
+			class := "application"
+			if frame.IsStandard() {
+				class = "standard"
+			}
+			_, _ = fmt.Fprintf(sb, "<li class=\"%s\"><code>%s</code> %s:%d", class, html.EscapeString(frame.FuncName()), html.EscapeString(frame.Filepath()), frame.Line())
+			if sources > 0 && !frame.IsStandard() {
+				if source, found := frame.Source(); found {
+					_, _ = fmt.Fprintf(sb, "<pre class=\"source\">%s</pre>", html.EscapeString(source))
+				}
+				sources--
+			}
+			_, _ = sb.WriteString("</li>\n")
+		}
+		_, _ = sb.WriteString("</ol>\n</details>\n")
+	}
+}
@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertToHTML() {
+	requireStack(suite.T())
+	err := errors.CreationFailed.With("<user>").(errors.Error).
+		WithHint("check the database").
+		WithDocURL("https://acme.com/errors/creation").
+		WithField("tenant", "acme").
+		WithCause(errors.ArgumentMissing.With("name")).
+		WithCause(fmt.Errorf("lookup: %w", errors.NotFound.With("group", "admins")))
+	page := errors.ToHTML(err)
+	suite.Assert().True(strings.HasPrefix(page, "<!DOCTYPE html>"))
+	suite.Assert().Contains(page, "<title>Failed Creating &lt;user&gt;</title>")
+	suite.Assert().Contains(page, `<span class="code">500</span> <span class="id">error.creation.failed</span> <span class="message">Failed Creating &lt;user&gt;</span></summary>`)
+	suite.Assert().Contains(page, `<p class="hint">Hint: check the database</p>`)
+	suite.Assert().Contains(page, `<a href="https://acme.com/errors/creation">`)
+	suite.Assert().Contains(page, "<tr><th>tenant</th><td>acme</td></tr>")
+	suite.Assert().Contains(page, "<summary>Stack trace</summary>")
+	suite.Assert().Contains(page, `<li class="standard"><code>testing.tRunner</code>`)
+	suite.Assert().Contains(page, `<span class="message">2 errors</span>`)
+	suite.Assert().Contains(page, `<span class="id">*fmt.wrapError</span> <span class="message">lookup: group admins Not Found</span>`)
+	suite.Assert().Contains(page, `<span class="id">error.notfound</span>`)
+	suite.Assert().Equal(strings.Count(page, "<details open>"), strings.Count(page, "</details>")-strings.Count(page, `<details class="stack">`))
+	suite.Assert().Equal(page, err.ToHTML())
+}
+
+func (suite *ErrorsSuite) TestShouldConvertNilErrorToEmptyHTML() {
+	suite.Assert().Empty(errors.ToHTML(nil))
+}
@@ -0,0 +1,146 @@
+package errors
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// HTTPBodyExcerptLength is the maximum number of bytes of the response body that WrapHTTP records
+const HTTPBodyExcerptLength = 512
+
+var (
+	httpHeaders = map[string]bool{
+		"Content-Type":     true,
+		"Retry-After":      true,
+		"Www-Authenticate": true,
+		"X-Request-Id":     true,
+		"X-Correlation-Id": true,
+	}
+	httpHeadersLock sync.RWMutex
+)
+
+// RegisterHTTPHeaders tells WrapHTTP to record the response headers with the given names
+//
+// By default, Content-Type, Retry-After, WWW-Authenticate, X-Request-Id, and X-Correlation-Id are recorded.
+func RegisterHTTPHeaders(names ...string) {
+	httpHeadersLock.Lock()
+	defer httpHeadersLock.Unlock()
+	for _, name := range names {
+		httpHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+}
+
+// UnregisterHTTPHeaders tells WrapHTTP to not record the response headers with the given names
+func UnregisterHTTPHeaders(names ...string) {
+	httpHeadersLock.Lock()
+	defer httpHeadersLock.Unlock()
+	for _, name := range names {
+		delete(httpHeaders, http.CanonicalHeaderKey(name))
+	}
+}
+
+// WrapHTTP converts the failure of an HTTP call into an Error that records its context
+//
+// If err is not nil, it gives the Error, see FromNet. The *url.Error wrapper of http.Client is removed.
+// Otherwise, if the response has an error status code, the Error is the sentinel of that code, see FromHTTPStatusCode.
+//
+// The URL of the request goes to the URL of the Error, with the values of its query redacted, see WithURL.
+//
+// The following attributes are added, when known:
+//
+//	"method"      the method of the request
+//	"status_code" the status code of the response
+//	"headers"     the registered headers of the response, see RegisterHTTPHeaders (map[string]string)
+//	"body"        the first HTTPBodyExcerptLength bytes of the response body
+//
+// The body of the response can still be read entirely after WrapHTTP.
+//
+// If err is nil and the response is not an error, WrapHTTP returns nil.
+//
+// Example:
+//
+//	res, err := client.Do(req)
+//	if err = errors.WrapHTTP(req, res, err); err != nil {
+//	  return err
+//	}
+func WrapHTTP(req *http.Request, res *http.Response, err error) error {
+	if err == nil && (res == nil || res.StatusCode < http.StatusBadRequest) {
+		return nil
+	}
+	fields := map[string]interface{}{}
+	location := ""
+	if req != nil {
+		fields["method"] = req.Method
+		if req.URL != nil {
+			location = redactURL(req.URL)
+		}
+	}
+	var final Error
+	if err != nil {
+		var urlError *url.Error
+		if As(err, &urlError) && urlError.Err != nil {
+			if len(location) == 0 {
+				if parsed, perr := url.Parse(urlError.URL); perr == nil {
+					location = redactURL(parsed)
+				}
+			}
+			err = urlError.Err
+		}
+		if details, ok := FromNet(err).(Error); ok {
+			final = details
+		}
+	} else {
+		final, _ = FromHTTPStatusCode(res.StatusCode).(Error)
+	}
+	if len(final.Stack) > 0 {
+		final.Stack.capture(3) // skip extern.go, capture, this func, so the trace starts at the caller
+	}
+	if res != nil {
+		fields["status_code"] = res.StatusCode
+		if headers := capturedHeaders(res.Header); len(headers) > 0 {
+			fields["headers"] = headers
+		}
+		if res.Body != nil {
+			excerpt, _ := io.ReadAll(io.LimitReader(res.Body, HTTPBodyExcerptLength))
+			res.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(excerpt), res.Body), res.Body}
+			if len(excerpt) > 0 {
+				fields["body"] = string(excerpt)
+			}
+		}
+	}
+	return final.WithURL(location).WithFields(fields)
+}
+
+// redactURL returns the given URL with the values of its query and its password redacted
+func redactURL(location *url.URL) string {
+	redacted := *location
+	if query := redacted.Query(); len(query) > 0 {
+		for key, values := range query {
+			for index := range values {
+				values[index] = "REDACTED"
+			}
+			query[key] = values
+		}
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.Redacted()
+}
+
+// capturedHeaders returns the registered headers of the given header
+func capturedHeaders(header http.Header) map[string]string {
+	httpHeadersLock.RLock()
+	defer httpHeadersLock.RUnlock()
+	captured := map[string]string{}
+	for name, values := range header {
+		if httpHeaders[http.CanonicalHeaderKey(name)] && len(values) > 0 {
+			captured[http.CanonicalHeaderKey(name)] = values[0]
+		}
+	}
+	return captured
+}
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HandlerFunc is an HTTP handler that returns an error
+//
+// When the handler returns an error, it is written to the client with WriteError.
+//
+// Example:
+//
+//	http.Handle("/users/", errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//	  user, err := db.FindUser(r.Context(), r.PathValue("id"))
+//	  if err != nil {
+//	    return err // The client gets a sanitized JSON error with the proper status code
+//	  }
+//	  return json.NewEncoder(w).Encode(user)
+//	}))
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls the handler and writes the error it returns, if any
+//
+// implements http.Handler
+func (handler HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := handler(w, r); err != nil {
+		WriteError(w, r, err)
+	}
+}
+
+// WriteError writes the given error to the client as JSON
+//
+// The request-scoped values of the request's context are copied into the error first, see WithContext.
+//
+// The error is sanitized before it is serialized, see Sanitize.
+//
+// The status code is the Code of the error, or http.StatusInternalServerError if it is not an HTTP error status.
+//
+// If the error or one of its causes carries a RateLimit, its headers are written as well, see RateLimit.Header.
+//
+// When the request is served through WarningsMiddleware, its warnings are written in the "warnings" property.
+//
+// When the request is served through ProblemMiddleware, the error is written as a problem document, see WriteProblem.
+//
+// If err is nil, WriteError writes nothing.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+	if r != nil {
+		err = WithContext(r.Context(), err)
+		if routeTypes, ok := r.Context().Value(problemContextKey{}).(map[string]ProblemType); ok {
+			writeProblem(w, r, err, routeTypes)
+			return
+		}
+	}
+	writeRateLimit(w, err)
+	sanitized := Sanitize(err).(Error)
+	if warnings := warningsOf(r).sanitized(); len(warnings) > 0 {
+		if payload, err := json.Marshal(warnings); err == nil {
+			sanitized.Extensions = map[string]json.RawMessage{"warnings": payload}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(statusCode(sanitized.Code))
+	_ = json.NewEncoder(w).Encode(sanitized)
+}
+
+// writeRateLimit writes the headers of the first RateLimit found in the chain of the given error, if any
+func writeRateLimit(w http.ResponseWriter, err error) {
+	for _, details := range ExtractAll[Error](err) {
+		if details.RateLimit != nil {
+			for key, values := range details.RateLimit.Header() {
+				w.Header()[key] = values
+			}
+			return
+		}
+	}
+}
+
+// statusCode returns the HTTP status code to use for the given Error Code
+func statusCode(code int) int {
+	if code < http.StatusBadRequest || code > 599 {
+		return http.StatusInternalServerError
+	}
+	return code
+}
@@ -0,0 +1,81 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanServeHTTPWithHandlerFunc() {
+	handler := errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("fail") == "" {
+			_, err := w.Write([]byte("OK"))
+			return err
+		}
+		return errors.NotFound.With("user", r.URL.Query().Get("fail"))
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users", nil))
+	suite.Assert().Equal(http.StatusOK, recorder.Code)
+	suite.Assert().Equal("OK", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users?fail=john", nil))
+	suite.Assert().Equal(http.StatusNotFound, recorder.Code)
+	suite.Assert().Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	var payload map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &payload))
+	suite.Assert().Equal("error.notfound", payload["id"])
+	suite.Assert().Equal("user", payload["what"])
+	suite.Assert().NotContains(payload, "stack", "the error should be sanitized")
+}
+
+func (suite *ErrorsSuite) TestCanWriteErrorPointer() {
+	recorder := httptest.NewRecorder()
+	errors.WriteError(recorder, httptest.NewRequest(http.MethodGet, "/users/john", nil), errors.NotFound.Clone())
+	suite.Assert().Equal(http.StatusNotFound, recorder.Code)
+
+	var payload map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &payload))
+	suite.Assert().Equal("error.notfound", payload["id"])
+	suite.Assert().Equal(errors.NotFound.Text, payload["text"])
+}
+
+func (suite *ErrorsSuite) TestCanWriteErrorWithSanitizedServerError() {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	errors.WriteError(recorder, request, fmt.Errorf("password=secret"))
+	suite.Assert().Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Assert().NotContains(recorder.Body.String(), "secret")
+
+	recorder = httptest.NewRecorder()
+	errors.WriteError(recorder, request, errors.DuplicateFound.With("user", "john"))
+	suite.Assert().Equal(http.StatusInternalServerError, recorder.Code, "non error status codes should become 500")
+
+	recorder = httptest.NewRecorder()
+	errors.WriteError(recorder, request, nil)
+	suite.Assert().Equal(http.StatusOK, recorder.Code)
+	suite.Assert().Empty(recorder.Body.String())
+}
+
+func (suite *ErrorsSuite) TestCanWriteErrorWithRateLimit() {
+	recorder := httptest.NewRecorder()
+	reset := time.Now().Add(30 * time.Second)
+	errors.WriteError(recorder, httptest.NewRequest(http.MethodGet, "/", nil), errors.HTTPStatusTooManyRequests.WithLimit(100, 0, reset).WithStack())
+	suite.Assert().Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Assert().Equal("100", recorder.Header().Get("X-RateLimit-Limit"))
+	suite.Assert().Equal("0", recorder.Header().Get("X-RateLimit-Remaining"))
+	suite.Assert().NotEmpty(recorder.Header().Get("Retry-After"))
+
+	recorder = httptest.NewRecorder()
+	errors.WriteError(recorder, httptest.NewRequest(http.MethodGet, "/", nil), errors.CreationFailed.Wrap(errors.HTTPStatusTooManyRequests.WithLimit(100, 0, reset)))
+	suite.Assert().Equal(http.StatusInternalServerError, recorder.Code)
+	suite.Assert().Equal("100", recorder.Header().Get("X-RateLimit-Limit"), "the RateLimit of the causes should be written")
+	suite.Assert().NotEmpty(recorder.Header().Get("Retry-After"))
+}
@@ -0,0 +1,104 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// WarningsTrailer is the HTTP trailer that contains the warnings of a request, see WarningsMiddleware
+const WarningsTrailer = "X-Warnings"
+
+// warningsContextKey is the key of the warnings of a request in a context.Context
+type warningsContextKey struct{}
+
+// requestWarnings collects the non-fatal errors of a request
+type requestWarnings struct {
+	lock   sync.Mutex
+	errors MultiError
+}
+
+// WarningsMiddleware returns a middleware that collects the non-fatal errors of the requests it serves
+//
+// The handlers add errors with AppendToRequest. They are sent to the client, sanitized, as:
+//
+//   - the "warnings" property of the errors written by WriteError,
+//   - the "warnings" member of the problem documents written by WriteProblem,
+//   - a JSON array in the WarningsTrailer HTTP trailer, for any response.
+//
+// Example:
+//
+//	mux.Handle("/orders", errors.WarningsMiddleware(errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//	  for _, item := range items {
+//	    if err := reserve(r.Context(), item); err != nil {
+//	      errors.AppendToRequest(r, err) // The order is still placed
+//	    }
+//	  }
+//	  return json.NewEncoder(w).Encode(order)
+//	})))
+func WarningsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		warnings := &requestWarnings{}
+		w.Header().Add("Trailer", WarningsTrailer)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), warningsContextKey{}, warnings)))
+		if sanitized := warnings.sanitized(); len(sanitized) > 0 {
+			if payload, err := json.Marshal(sanitized); err == nil {
+				w.Header().Set(WarningsTrailer, string(payload))
+			}
+		}
+	})
+}
+
+// AppendToRequest appends non-fatal errors to the warnings of the given request
+//
+// If the request is not served through WarningsMiddleware, the errors are ignored.
+//
+// If an error is nil, it is not added
+func AppendToRequest(r *http.Request, errs ...error) {
+	if warnings := warningsOf(r); warnings != nil {
+		warnings.lock.Lock()
+		defer warnings.lock.Unlock()
+		warnings.errors.Append(errs...)
+	}
+}
+
+// WarningsFromRequest returns a copy of the warnings of the given request
+//
+// If the request is not served through WarningsMiddleware, WarningsFromRequest returns nil.
+func WarningsFromRequest(r *http.Request) *MultiError {
+	warnings := warningsOf(r)
+	if warnings == nil {
+		return nil
+	}
+	warnings.lock.Lock()
+	defer warnings.lock.Unlock()
+	return &MultiError{Errors: slices.Clone(warnings.errors.Errors)}
+}
+
+// warningsOf returns the warnings of the given request, if any
+func warningsOf(r *http.Request) *requestWarnings {
+	if r == nil {
+		return nil
+	}
+	warnings, _ := r.Context().Value(warningsContextKey{}).(*requestWarnings)
+	return warnings
+}
+
+// sanitized returns the warnings, sanitized, see Sanitize
+func (warnings *requestWarnings) sanitized() []Error {
+	if warnings == nil {
+		return nil
+	}
+	warnings.lock.Lock()
+	defer warnings.lock.Unlock()
+	if warnings.errors.IsEmpty() {
+		return nil
+	}
+	sanitized := make([]Error, 0, len(warnings.errors.Errors))
+	for _, err := range warnings.errors.Errors {
+		sanitized = append(sanitized, Sanitize(err).(Error))
+	}
+	return sanitized
+}
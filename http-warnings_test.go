@@ -0,0 +1,65 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanAccumulateRequestWarnings() {
+	handler := errors.WarningsMiddleware(errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		errors.AppendToRequest(r, errors.NotFound.With("item", "12"), nil)
+		errors.AppendToRequest(r, fmt.Errorf("password=secret"))
+		suite.Assert().Len(errors.WarningsFromRequest(r).Errors, 2)
+		_, err := w.Write([]byte("OK"))
+		return err
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	response := recorder.Result()
+	suite.Assert().Equal(http.StatusOK, response.StatusCode)
+	suite.Assert().Equal("OK", recorder.Body.String())
+
+	var warnings []map[string]interface{}
+	suite.Require().NoError(json.Unmarshal([]byte(response.Trailer.Get(errors.WarningsTrailer)), &warnings))
+	suite.Require().Len(warnings, 2)
+	suite.Assert().Equal("error.notfound", warnings[0]["id"])
+	suite.Assert().Equal("error.runtime", warnings[1]["id"])
+	suite.Assert().NotContains(response.Trailer.Get(errors.WarningsTrailer), "secret", "the warnings should be sanitized")
+}
+
+func (suite *ErrorsSuite) TestCanWriteErrorWithRequestWarnings() {
+	handler := errors.WarningsMiddleware(errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		errors.AppendToRequest(r, errors.NotFound.With("item", "12"))
+		return errors.ArgumentMissing.With("name")
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	suite.Assert().Equal(http.StatusBadRequest, recorder.Code)
+
+	var payload map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &payload))
+	suite.Assert().Equal("error.argument.missing", payload["id"])
+	suite.Require().Contains(payload, "warnings")
+	suite.Assert().Len(payload["warnings"], 1)
+
+	recorder = httptest.NewRecorder()
+	errors.ProblemMiddleware(nil)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	var problem errors.Problem
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &problem))
+	suite.Assert().Equal("error.argument.missing", problem.Code)
+	suite.Require().Len(problem.Warnings, 1)
+	suite.Assert().Equal("error.notfound", problem.Warnings[0].Code)
+}
+
+func (suite *ErrorsSuite) TestCannotAccumulateWarningsWithoutMiddleware() {
+	request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	errors.AppendToRequest(request, errors.NotFound.With("item", "12"))
+	suite.Assert().Nil(errors.WarningsFromRequest(request))
+	suite.Assert().True(errors.WarningsFromRequest(request).IsEmpty())
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"io"
+)
+
+// IOOption configures how FromIO converts errors
+type IOOption func(options *ioOptions)
+
+type ioOptions struct {
+	ignoreEOF bool
+}
+
+// IgnoreEOF tells FromIO to return nil for io.EOF, as it usually means the stream was read entirely
+func IgnoreEOF() IOOption {
+	return func(options *ioOptions) {
+		options.ignoreEOF = true
+	}
+}
+
+// FromIO converts an error from the io package into an Error
+//
+// The errors are mapped to the following sentinels:
+//
+//	io.EOF               -> EndOfStream (or nil, see IgnoreEOF)
+//	io.ErrUnexpectedEOF  -> UnexpectedEndOfStream
+//	io.ErrShortWrite     -> ShortWrite
+//	io.ErrClosedPipe     -> ClosedPipe
+//
+// If err is nil, FromIO returns nil. Errors and *Error are returned as is.
+func FromIO(err error, options ...IOOption) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	config := ioOptions{}
+	for _, option := range options {
+		option(&config)
+	}
+	var final Error
+	switch {
+	case Is(err, io.EOF):
+		if config.ignoreEOF {
+			return nil
+		}
+		final = EndOfStream.derive()
+	case Is(err, io.ErrUnexpectedEOF):
+		final = UnexpectedEndOfStream.derive()
+	case Is(err, io.ErrShortWrite):
+		final = ShortWrite.derive()
+	case Is(err, io.ErrClosedPipe):
+		final = ClosedPipe.derive()
+	default:
+		return WithStack(err)
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
@@ -0,0 +1,37 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertIOErrors() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromIO(nil))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromIO(pointer), "a *Error should be returned as is")
+
+	_, err := io.ReadFull(strings.NewReader("abc"), make([]byte, 8))
+	err = errors.FromIO(err)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.UnexpectedEndOfStream)
+	suite.Assert().ErrorIs(err, io.ErrUnexpectedEOF)
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+
+	suite.Assert().ErrorIs(errors.FromIO(io.EOF), errors.EndOfStream)
+	suite.Assert().ErrorIs(errors.FromIO(fmt.Errorf("write: %w", io.ErrShortWrite)), errors.ShortWrite)
+	suite.Assert().ErrorIs(errors.FromIO(io.ErrClosedPipe), errors.ClosedPipe)
+
+	err = errors.FromIO(io.ErrNoProgress)
+	suite.Assert().ErrorIs(err, io.ErrNoProgress)
+	suite.Assert().Equal("error.runtime", err.(errors.Error).ID)
+}
+
+func (suite *ErrorsSuite) TestCanIgnoreEOFWithFromIO() {
+	suite.Assert().Nil(errors.FromIO(io.EOF, errors.IgnoreEOF()))
+	suite.Assert().Nil(errors.FromIO(fmt.Errorf("read: %w", io.EOF), errors.IgnoreEOF()))
+	suite.Assert().ErrorIs(errors.FromIO(io.ErrUnexpectedEOF, errors.IgnoreEOF()), errors.UnexpectedEndOfStream)
+}
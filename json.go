@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromJSON converts an error from encoding/json into a JSONUnmarshalError with the location of the problem
+//
+// For *json.SyntaxError and *json.UnmarshalTypeError, the "offset" attribute contains the offset of the problem
+// in the payload, and the "line" and "column" attributes its location, counted from 1.
+// For *json.UnmarshalTypeError, the "path" attribute contains the path of the field, like: "items[3].price",
+// its JSON Pointer goes to the Pointer of the error, like: "/items/3/price",
+// the "expected" attribute contains the Go type, and the "actual" attribute the JSON value.
+//
+// The message of the error tells what happened and where, like:
+//
+//	JSON failed to unmarshal data: invalid type at items[3].price, expected float64, got string (line 12, column 18)
+//
+// The payload is the JSON data that failed to unmarshal, if it is nil, the line and column are not computed.
+//
+// The given error becomes the Cause. Errors are returned as is. If err is nil, FromJSON returns nil.
+//
+// FromJSON also records the stack trace at the point it was called.
+//
+// Example:
+//
+//	if err := json.Unmarshal(payload, &order); err != nil {
+//	  return errors.FromJSON(err, payload)
+//	}
+func FromJSON(err error, payload []byte) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	final := JSONUnmarshalError.derive()
+	attributes := map[string]interface{}{}
+	var problem string
+	var offset int64 = -1
+
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case As(err, &syntaxError):
+		offset = syntaxError.Offset
+		problem = syntaxError.Error()
+	case As(err, &typeError):
+		offset = typeError.Offset
+		problem = "invalid type"
+		if len(typeError.Field) > 0 {
+			path := jsonPath(typeError.Field)
+			attributes["path"] = path
+			final.Pointer = pathToPointer(path)
+			problem += " at " + path
+		}
+		if typeError.Type != nil {
+			attributes["expected"] = typeError.Type.String()
+			problem += ", expected " + typeError.Type.String()
+		}
+		attributes["actual"] = typeError.Value
+		problem += ", got " + typeError.Value
+	}
+	if offset >= 0 {
+		attributes["offset"] = offset
+		if payload != nil {
+			line, column := jsonLocation(payload, offset)
+			attributes["line"] = line
+			attributes["column"] = column
+			problem += fmt.Sprintf(" (line %d, column %d)", line, column)
+		}
+	}
+	if len(problem) > 0 {
+		final.Text += ": " + strings.ReplaceAll(problem, "%", "%%")
+	}
+	if len(attributes) > 0 {
+		final.Attributes = attributes
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// jsonLocation gives the line and column of the last byte read before the given offset in the given payload, counted from 1
+//
+// encoding/json reports the offset after the byte that caused the problem.
+func jsonLocation(payload []byte, offset int64) (line int, column int) {
+	if offset > int64(len(payload)) {
+		offset = int64(len(payload))
+	}
+	if offset > 0 {
+		offset-- // the problem is at the last byte read
+	}
+	before := payload[:offset]
+	line = bytes.Count(before, []byte{'\n'}) + 1
+	column = len(before) - bytes.LastIndexByte(before, '\n')
+	return
+}
+
+// jsonPath converts the given field path of encoding/json into a JSON path, like: "items.3.price" into "items[3].price"
+func jsonPath(field string) string {
+	segments := strings.Split(field, ".")
+	var path strings.Builder
+	for index, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil && index > 0 {
+			path.WriteString("[" + segment + "]")
+			continue
+		}
+		if index > 0 {
+			path.WriteString(".")
+		}
+		path.WriteString(segment)
+	}
+	return path.String()
+}
@@ -0,0 +1,64 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+type jsonOrder struct {
+	Items []struct {
+		Price float64 `json:"price"`
+	} `json:"items"`
+}
+
+func (suite *ErrorsSuite) TestCanConvertJSONTypeErrors() {
+	requireStack(suite.T())
+	payload := []byte("{\n  \"items\": [\n    {\"price\": \"cheap\"}\n  ]\n}")
+	var order jsonOrder
+	err := errors.FromJSON(json.Unmarshal(payload, &order), payload)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.JSONUnmarshalError)
+	var typeError *json.UnmarshalTypeError
+	suite.Assert().ErrorAs(err, &typeError)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("items[0].price", details.Attributes["path"])
+	suite.Assert().Equal("float64", details.Attributes["expected"])
+	suite.Assert().Equal("string", details.Attributes["actual"])
+	suite.Assert().Equal(3, details.Attributes["line"])
+	suite.Assert().Equal(21, details.Attributes["column"])
+	suite.Assert().Equal("JSON failed to unmarshal data: invalid type at items[0].price, expected float64, got string (line 3, column 21)", strings.Split(details.Error(), "\n")[0])
+	suite.Assert().NotEmpty(details.Stack)
+}
+
+func (suite *ErrorsSuite) TestCanConvertJSONSyntaxErrors() {
+	payload := []byte("{\n  \"items\": [\n    {\"price\" 12}\n  ]\n}")
+	var order jsonOrder
+	err := errors.FromJSON(json.Unmarshal(payload, &order), payload)
+	suite.Require().Error(err)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal(3, details.Attributes["line"])
+	suite.Assert().Equal(14, details.Attributes["column"])
+	suite.Assert().Contains(details.Error(), "invalid character '1' after object key (line 3, column 14)")
+
+	err = errors.FromJSON(json.Unmarshal(payload, &order), nil)
+	suite.Assert().NotContains(err.(errors.Error).Attributes, "line")
+	suite.Assert().Contains(err.(errors.Error).Attributes, "offset")
+}
+
+func (suite *ErrorsSuite) TestCanConvertOtherJSONErrors() {
+	suite.Assert().Nil(errors.FromJSON(nil, nil))
+
+	err := errors.FromJSON(fmt.Errorf("100%% broken"), nil)
+	suite.Assert().ErrorIs(err, errors.JSONUnmarshalError)
+	suite.Assert().Nil(err.(errors.Error).Attributes)
+
+	original := errors.ArgumentMissing.With("key")
+	suite.Assert().Equal(original, errors.FromJSON(original, nil))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromJSON(pointer, nil), "a *Error should be returned as is")
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	jwtErrors     = []jwtError{}
+	jwtErrorsLock sync.RWMutex
+)
+
+// jwtError maps an error of a JWT library to a sentinel
+type jwtError struct {
+	err      error
+	sentinel Error
+}
+
+// RegisterJWTError registers an error of a JWT library that FromJWT will map to the given sentinel
+//
+// The errors are checked in the order they were registered.
+// If the error was already registered, its sentinel is replaced.
+//
+// Example, for github.com/golang-jwt/jwt/v5:
+//
+//	errors.RegisterJWTError(jwt.ErrTokenExpired, errors.TokenExpired)
+//	errors.RegisterJWTError(jwt.ErrTokenSignatureInvalid, errors.TokenInvalid)
+func RegisterJWTError(err error, sentinel Error) {
+	jwtErrorsLock.Lock()
+	defer jwtErrorsLock.Unlock()
+	for index, item := range jwtErrors {
+		if Is(item.err, err) {
+			jwtErrors[index].sentinel = sentinel
+			return
+		}
+	}
+	jwtErrors = append(jwtErrors, jwtError{err: err, sentinel: sentinel})
+}
+
+// UnregisterJWTError removes the given error of a JWT library from the errors FromJWT maps
+func UnregisterJWTError(err error) {
+	jwtErrorsLock.Lock()
+	defer jwtErrorsLock.Unlock()
+	for index, item := range jwtErrors {
+		if Is(item.err, err) {
+			jwtErrors = append(jwtErrors[:index], jwtErrors[index+1:]...)
+			return
+		}
+	}
+}
+
+// FromJWT converts an error of a JWT library into an Error
+//
+// The errors registered with RegisterJWTError are checked first, with errors.Is.
+// Then, the messages of the common JWT libraries are recognized:
+//
+//	"token is expired", "exp not satisfied"                    -> TokenExpired
+//	"token is missing", "no token", "token not found"          -> TokenMissing
+//	"token is malformed", "signature is invalid", and others   -> TokenInvalid
+//
+// If err is nil, FromJWT returns nil. Errors and *Error are returned as is.
+func FromJWT(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	final, found := registeredJWTSentinel(err)
+	if !found {
+		message := strings.ToLower(err.Error())
+		switch {
+		case containsAny(message, "expired", "exp not satisfied"):
+			final = TokenExpired.derive()
+		case containsAny(message, "token is missing", "no token", "token not found", "token is empty"):
+			final = TokenMissing.derive()
+		default:
+			final = TokenInvalid.derive()
+		}
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// registeredJWTSentinel gives the sentinel registered for the given error, if any
+func registeredJWTSentinel(err error) (Error, bool) {
+	jwtErrorsLock.RLock()
+	defer jwtErrorsLock.RUnlock()
+	for _, item := range jwtErrors {
+		if Is(err, item.err) {
+			return item.sentinel.derive(), true
+		}
+	}
+	return Error{}, false
+}
@@ -0,0 +1,72 @@
+package errors_test
+
+import (
+	goerrors "errors"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertJWTErrors() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromJWT(nil))
+
+	// like github.com/golang-jwt/jwt/v5
+	expired := goerrors.New("token is expired")
+	err := errors.FromJWT(fmt.Errorf("%w: %w", goerrors.New("token has invalid claims"), expired))
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.TokenExpired)
+	suite.Assert().ErrorIs(err, expired)
+	suite.Assert().True(errors.IsCode(err, 401))
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+
+	err = errors.FromJWT(goerrors.New("token signature is invalid: crypto/rsa: verification error"))
+	suite.Assert().ErrorIs(err, errors.TokenInvalid)
+
+	err = errors.FromJWT(goerrors.New("no token present in request"))
+	suite.Assert().ErrorIs(err, errors.TokenMissing)
+
+	original := errors.SessionExpired.WithStack()
+	suite.Assert().Equal(original, errors.FromJWT(original))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromJWT(pointer), "a *Error should be returned as is")
+}
+
+func (suite *ErrorsSuite) TestCanConvertRegisteredJWTErrors() {
+	notBefore := goerrors.New("nbf claim is in the future")
+	errors.RegisterJWTError(notBefore, errors.MFARequired) // any sentinel can be used
+	defer errors.UnregisterJWTError(notBefore)
+	err := errors.FromJWT(fmt.Errorf("validation: %w", notBefore))
+	suite.Assert().ErrorIs(err, errors.MFARequired)
+
+	errors.RegisterJWTError(notBefore, errors.SessionExpired)
+	err = errors.FromJWT(notBefore)
+	suite.Assert().ErrorIs(err, errors.SessionExpired, "the sentinel should have been replaced")
+	suite.Assert().NotErrorIs(err, errors.MFARequired)
+
+	errors.UnregisterJWTError(notBefore)
+	suite.Assert().ErrorIs(errors.FromJWT(notBefore), errors.TokenInvalid)
+}
+
+func (suite *ErrorsSuite) TestCanConvertJWTErrorsWithFrozenSentinels() {
+	errors.FreezeSentinels(true)
+	defer errors.FreezeSentinels(false)
+
+	revoked := goerrors.New("token has been revoked")
+	errors.RegisterJWTError(revoked, errors.SessionExpired)
+	defer errors.UnregisterJWTError(revoked)
+	for _, cause := range []error{revoked, goerrors.New("token is expired")} {
+		err := errors.FromJWT(cause)
+		suite.Require().Error(err)
+		suite.Assert().NotPanics(func() { _ = err.(errors.Error).WithField("user", "john") })
+		suite.Assert().NotPanics(func() { _ = errors.WithStack(err) })
+	}
+}
+
+func (suite *ErrorsSuite) TestCanUseAuthenticationSentinels() {
+	for _, sentinel := range []errors.Error{errors.MFARequired, errors.SessionExpired, errors.TokenExpired, errors.TokenInvalid, errors.TokenMissing} {
+		suite.Assert().Equal(401, sentinel.Code, "Code of %s", sentinel.ID)
+		suite.Assert().NoError(errors.ValidateID(sentinel.ID))
+	}
+	suite.Assert().Equal(403, errors.PermissionDenied.Code)
+}
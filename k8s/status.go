@@ -0,0 +1,218 @@
+// Package k8s converts errors of github.com/gildas/go-errors from and to Kubernetes metav1.Status and apierrors.StatusError.
+//
+// This package is a separate module, so the main module does not depend on k8s.io/apimachinery.
+//
+// Operators and admission webhooks can return idiomatic Kubernetes error responses.
+//
+// Example:
+//
+//	if err := reconcile(ctx, deployment); err != nil {
+//	  return k8s.ToStatusError(err)
+//	}
+package k8s
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gildas/go-errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reasons maps the IDs of sentinels to Kubernetes reasons
+var reasons = []struct {
+	sentinel errors.Error
+	reason   metav1.StatusReason
+}{
+	{errors.NotFound, metav1.StatusReasonNotFound},
+	{errors.DuplicateFound, metav1.StatusReasonAlreadyExists},
+	{errors.Conflict, metav1.StatusReasonConflict},
+	{errors.ValidationFailed, metav1.StatusReasonInvalid},
+	{errors.Unauthorized, metav1.StatusReasonUnauthorized},
+	{errors.PermissionDenied, metav1.StatusReasonForbidden},
+	{errors.Timeout, metav1.StatusReasonTimeout},
+}
+
+// codeReasons maps HTTP status codes to Kubernetes reasons
+var codeReasons = map[int]metav1.StatusReason{
+	http.StatusBadRequest:            metav1.StatusReasonBadRequest,
+	http.StatusUnauthorized:          metav1.StatusReasonUnauthorized,
+	http.StatusForbidden:             metav1.StatusReasonForbidden,
+	http.StatusNotFound:              metav1.StatusReasonNotFound,
+	http.StatusMethodNotAllowed:      metav1.StatusReasonMethodNotAllowed,
+	http.StatusNotAcceptable:         metav1.StatusReasonNotAcceptable,
+	http.StatusConflict:              metav1.StatusReasonConflict,
+	http.StatusGone:                  metav1.StatusReasonGone,
+	http.StatusRequestEntityTooLarge: metav1.StatusReasonRequestEntityTooLarge,
+	http.StatusUnsupportedMediaType:  metav1.StatusReasonUnsupportedMediaType,
+	http.StatusUnprocessableEntity:   metav1.StatusReasonInvalid,
+	http.StatusTooManyRequests:       metav1.StatusReasonTooManyRequests,
+	http.StatusInternalServerError:   metav1.StatusReasonInternalError,
+	http.StatusServiceUnavailable:    metav1.StatusReasonServiceUnavailable,
+	http.StatusGatewayTimeout:        metav1.StatusReasonTimeout,
+}
+
+// ToStatus converts the given error into a failure metav1.Status
+//
+// The Code and the Reason come from the first errors.Error of err's chain,
+// the message is the compact form of the whole chain.
+// The FieldError of the chain, like the ones of errors.ValidationErrors, become the causes of the Status.
+//
+// If err is nil, ToStatus returns a success Status.
+func ToStatus(err error) *metav1.Status {
+	if err == nil {
+		return &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusSuccess, Code: http.StatusOK}
+	}
+	status := &metav1.Status{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"},
+		Status:   metav1.StatusFailure,
+		Message:  fmt.Sprintf("%-v", err),
+		Code:     http.StatusInternalServerError,
+	}
+	details := metav1.StatusDetails{}
+	var final errors.Error
+	if errors.As(err, &final) {
+		if final.Code > 0 {
+			status.Code = int32(final.Code)
+		}
+		if value, ok := final.Value.(string); ok && len(final.What) > 0 && len(value) > 0 {
+			details.Kind = final.What
+			details.Name = value
+		} else {
+			details.Name = final.What
+		}
+	}
+	status.Reason = reasonOf(final, int(status.Code))
+	errors.Walk(err, func(cause error) bool {
+		if fieldError, ok := cause.(errors.FieldError); ok {
+			details.Causes = append(details.Causes, metav1.StatusCause{
+				Type:    causeType(fieldError.Rule),
+				Message: fieldError.Message,
+				Field:   fieldError.Field,
+			})
+		}
+		return true
+	})
+	if len(details.Name) > 0 || len(details.Kind) > 0 || len(details.Causes) > 0 {
+		status.Details = &details
+	}
+	return status
+}
+
+// ToStatusError converts the given error into an apierrors.StatusError, see ToStatus
+//
+// If err is nil, ToStatusError returns nil.
+func ToStatusError(err error) *apierrors.StatusError {
+	if err == nil {
+		return nil
+	}
+	return &apierrors.StatusError{ErrStatus: *ToStatus(err)}
+}
+
+// FromStatus converts the given metav1.Status into an error
+//
+// The reason is mapped to the corresponding sentinel, so errors.Is(err, errors.NotFound) works,
+// the message of the Status becomes the Text of the error,
+// and the causes become errors.FieldError in an errors.ValidationErrors cause.
+//
+// If the Status is nil or a success, FromStatus returns nil.
+func FromStatus(status *metav1.Status) error {
+	if status == nil || status.Status == metav1.StatusSuccess || (len(status.Status) == 0 && status.Code >= 200 && status.Code < 300) {
+		return nil
+	}
+	final := errors.Error{Code: int(status.Code), ID: "error.kubernetes." + strings.ToLower(string(status.Reason))}
+	if sentinel, found := sentinelOf(status.Reason); found {
+		final.ID = sentinel.ID
+		if final.Code == 0 {
+			final.Code = sentinel.Code
+		}
+	}
+	if len(status.Reason) == 0 {
+		final.ID = "error.kubernetes"
+	}
+	final.Text = strings.ReplaceAll(status.Message, "%", "%%")
+	if status.Details != nil {
+		attributes := map[string]interface{}{}
+		if len(status.Reason) > 0 {
+			attributes["reason"] = string(status.Reason)
+		}
+		if len(status.Details.Kind) > 0 {
+			attributes["kind"] = status.Details.Kind
+		}
+		if len(status.Details.Name) > 0 {
+			attributes["name"] = status.Details.Name
+		}
+		if len(status.Details.Group) > 0 {
+			attributes["group"] = status.Details.Group
+		}
+		if status.Details.RetryAfterSeconds > 0 {
+			attributes["retry_after_seconds"] = status.Details.RetryAfterSeconds
+			final.Retryable = true
+		}
+		final.Attributes = attributes
+		if len(status.Details.Causes) > 0 {
+			causes := &errors.ValidationErrors{}
+			for _, cause := range status.Details.Causes {
+				fieldError := errors.ValidationFailed.ForField(cause.Field, string(cause.Type), nil)
+				if len(cause.Message) > 0 {
+					fieldError.Message = cause.Message
+				}
+				causes.Append(fieldError)
+			}
+			final.Cause = causes
+		}
+	}
+	return final.WithStack()
+}
+
+// FromStatusError converts the apierrors.StatusError of err's chain into an error, see FromStatus
+//
+// If there is no apierrors.StatusError in err's chain, err is returned unchanged.
+func FromStatusError(err error) error {
+	var statusError *apierrors.StatusError
+	if !errors.As(err, &statusError) {
+		return err
+	}
+	return FromStatus(&statusError.ErrStatus)
+}
+
+// reasonOf gives the Kubernetes reason of the given Error and code
+func reasonOf(final errors.Error, code int) metav1.StatusReason {
+	for _, item := range reasons {
+		if len(final.ID) > 0 && final.ID == item.sentinel.ID {
+			return item.reason
+		}
+	}
+	if reason, found := codeReasons[code]; found {
+		return reason
+	}
+	if code >= 500 {
+		return metav1.StatusReasonInternalError
+	}
+	return metav1.StatusReasonUnknown
+}
+
+// sentinelOf gives the sentinel of the given Kubernetes reason
+func sentinelOf(reason metav1.StatusReason) (errors.Error, bool) {
+	for _, item := range reasons {
+		if item.reason == reason {
+			return item.sentinel, true
+		}
+	}
+	return errors.Error{}, false
+}
+
+// causeType gives the Kubernetes cause type of the given validation rule
+func causeType(rule string) metav1.CauseType {
+	switch rule {
+	case "required":
+		return metav1.CauseTypeFieldValueRequired
+	case "oneof":
+		return metav1.CauseTypeFieldValueNotSupported
+	case "":
+		return metav1.CauseTypeFieldValueInvalid
+	}
+	return metav1.CauseType(rule)
+}
@@ -0,0 +1,116 @@
+package k8s_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-errors/k8s"
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type K8sSuite struct {
+	suite.Suite
+}
+
+func TestK8sSuite(t *testing.T) {
+	suite.Run(t, new(K8sSuite))
+}
+
+func (suite *K8sSuite) TestCanConvertToStatus() {
+	status := k8s.ToStatus(errors.NotFound.With("deployment", "web"))
+	suite.Assert().Equal("Status", status.Kind)
+	suite.Assert().Equal("v1", status.APIVersion)
+	suite.Assert().Equal(metav1.StatusFailure, status.Status)
+	suite.Assert().Equal(metav1.StatusReasonNotFound, status.Reason)
+	suite.Assert().Equal(int32(http.StatusNotFound), status.Code)
+	suite.Assert().Equal("deployment web Not Found", status.Message)
+	suite.Require().NotNil(status.Details)
+	suite.Assert().Equal("deployment", status.Details.Kind)
+	suite.Assert().Equal("web", status.Details.Name)
+
+	status = k8s.ToStatus(nil)
+	suite.Assert().Equal(metav1.StatusSuccess, status.Status)
+
+	status = k8s.ToStatus(fmt.Errorf("boom"))
+	suite.Assert().Equal(metav1.StatusReasonInternalError, status.Reason)
+	suite.Assert().Equal(int32(500), status.Code)
+}
+
+func (suite *K8sSuite) TestCanConvertToStatusError() {
+	err := k8s.ToStatusError(errors.NotFound.With("deployment", "web"))
+	suite.Require().NotNil(err)
+	suite.Assert().True(apierrors.IsNotFound(err), "apimachinery should recognize the StatusError")
+	suite.Assert().Equal("deployment web Not Found", err.Error())
+	suite.Assert().True(apierrors.IsInvalid(k8s.ToStatusError(errors.ValidationFailed.WithStack())))
+	suite.Assert().Nil(k8s.ToStatusError(nil))
+}
+
+func (suite *K8sSuite) TestCanConvertValidationErrorsToStatus() {
+	errs := &errors.ValidationErrors{}
+	errs.Add("spec.replicas", "required", nil)
+	errs.Add("spec.image", "format", "nginx:")
+	status := k8s.ToStatus(errs.AsError())
+	suite.Assert().Equal(metav1.StatusReasonInvalid, status.Reason)
+	suite.Assert().Equal(int32(422), status.Code)
+	suite.Require().NotNil(status.Details)
+	suite.Require().Len(status.Details.Causes, 2)
+	suite.Assert().Equal(metav1.StatusCause{Type: metav1.CauseTypeFieldValueRequired, Message: "Validation failed for spec.replicas", Field: "spec.replicas"}, status.Details.Causes[0])
+	suite.Assert().Equal(metav1.CauseType("format"), status.Details.Causes[1].Type)
+
+	payload, err := json.Marshal(status)
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(payload), `"causes":[{"reason":"FieldValueRequired"`)
+}
+
+func (suite *K8sSuite) TestCanConvertFromStatus() {
+	suite.Assert().Nil(k8s.FromStatus(&metav1.Status{Status: metav1.StatusSuccess}))
+	suite.Assert().Nil(k8s.FromStatus(nil))
+
+	err := k8s.FromStatus(&metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: `deployments.apps "web" not found`,
+		Reason:  "NotFound",
+		Code:    404,
+		Details: &metav1.StatusDetails{Name: "web", Group: "apps", Kind: "deployments"},
+	})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal(`deployments.apps "web" not found`, details.Error())
+	suite.Assert().Equal("web", details.Attributes["name"])
+	suite.Assert().Equal("apps", details.Attributes["group"])
+
+	err = k8s.FromStatus(&metav1.Status{
+		Status:  metav1.StatusFailure,
+		Reason:  metav1.StatusReasonInvalid,
+		Code:    422,
+		Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueRequired, Message: "Required value", Field: "spec.replicas"}}},
+	})
+	suite.Assert().ErrorIs(err, errors.ValidationFailed)
+	var fieldError errors.FieldError
+	suite.Require().ErrorAs(err, &fieldError)
+	suite.Assert().Equal("spec.replicas", fieldError.Field)
+	suite.Assert().Equal("Required value", fieldError.Message)
+
+	err = k8s.FromStatus(&metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonTooManyRequests, Code: 429, Message: "slow down"})
+	suite.Assert().Equal("error.kubernetes.toomanyrequests", err.(errors.Error).ID)
+	suite.Assert().True(errors.IsCode(err, 429))
+}
+
+func (suite *K8sSuite) TestCanConvertFromStatusError() {
+	apiErr := apierrors.NewAlreadyExists(schema.GroupResource{Resource: "pods"}, "web-0")
+	err := k8s.FromStatusError(fmt.Errorf("create: %w", apiErr))
+	suite.Assert().ErrorIs(err, errors.DuplicateFound)
+	suite.Assert().True(errors.IsCode(err, 409))
+	suite.Assert().Equal(`pods "web-0" already exists`, err.Error())
+
+	plain := fmt.Errorf("plain")
+	suite.Assert().Equal(plain, k8s.FromStatusError(plain))
+}
@@ -0,0 +1,50 @@
+package errors
+
+import "path"
+
+// Match tells if any error in err's chain has an ID matching the given glob pattern
+//
+// The pattern uses the syntax of path.Match, where "*" matches any sequence of characters
+// but the dot, like: "error.http.*" or "error.*.missing"
+//
+// The contents of MultiError are matched as well.
+//
+// If the pattern is malformed, Match returns false.
+func Match(err error, pattern string) bool {
+	if _, perr := path.Match(dotsToSlashes(pattern), ""); perr != nil {
+		return false
+	}
+	return Is(err, patternTarget(pattern))
+}
+
+// MatchID tells if this Error has an ID matching the given glob pattern
+//
+// See Match for the syntax of the pattern.
+func (e Error) MatchID(pattern string) bool {
+	matched, _ := path.Match(dotsToSlashes(pattern), dotsToSlashes(e.ID))
+	return matched
+}
+
+// patternTarget is used by Match to match errors with Is
+type patternTarget string
+
+// Error returns the string version of this patternTarget
+//
+// implements error interface
+func (pattern patternTarget) Error() string {
+	return string(pattern)
+}
+
+// dotsToSlashes swaps the dots and the slashes of the given ID or pattern so it can be matched by path.Match
+func dotsToSlashes(id string) string {
+	converted := []byte(id)
+	for i, c := range converted {
+		switch c {
+		case '.':
+			converted[i] = '/'
+		case '/':
+			converted[i] = '.'
+		}
+	}
+	return string(converted)
+}
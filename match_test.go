@@ -0,0 +1,32 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanMatchID() {
+	suite.Assert().True(errors.HTTPNotFound.MatchID("error.http.*"))
+	suite.Assert().True(errors.ArgumentMissing.MatchID("error.*.missing"))
+	suite.Assert().True(errors.ArgumentMissing.MatchID("error.argument.missing"))
+	suite.Assert().True(errors.ArgumentMissing.MatchID("error.argument.m?ssing"))
+	suite.Assert().False(errors.HTTPStatusGatewayTimeout.MatchID("error.http.*"), "* should not match dots")
+	suite.Assert().True(errors.HTTPStatusGatewayTimeout.MatchID("error.http.*.*"))
+	suite.Assert().False(errors.NotFound.MatchID("error.*.missing"))
+	suite.Assert().False(errors.NotFound.MatchID("error.[.missing"))
+}
+
+func (suite *ErrorsSuite) TestCanMatchErrors() {
+	err := errors.WrapErrors(errors.NotFound.With("user", "john"), errors.ArgumentMissing.With("name"), fmt.Errorf("simple error"))
+	suite.Assert().True(errors.Match(err, "error.notfound"))
+	suite.Assert().True(errors.Match(err, "error.*.missing"))
+	suite.Assert().False(errors.Match(err, "error.http.*"))
+	suite.Assert().False(errors.Match(err, "error.[.missing"), "malformed patterns should not match")
+	suite.Assert().False(errors.Match(nil, "*"))
+
+	var errs errors.MultiError
+	errs.Append(errors.New("this is error 1"), errors.HTTPNotFound.WithStack())
+	suite.Assert().True(errors.Match(errs.AsError(), "error.http.*"))
+	suite.Assert().False(errors.Match(errs.AsError(), "error.*.missing"))
+}
@@ -2,6 +2,8 @@ package errors
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 )
 
@@ -14,7 +16,7 @@ type MultiError struct {
 //
 // implements error.Error interface
 func (me *MultiError) Error() string {
-	if len(me.Errors) == 0 {
+	if me == nil || len(me.Errors) == 0 {
 		return ""
 	}
 	if len(me.Errors) == 1 {
@@ -28,6 +30,74 @@ func (me *MultiError) Error() string {
 	return fmt.Sprintf("%d errors:%s", len(me.Errors), text.String())
 }
 
+// Format interprets fmt State and rune to generate an output for fmt.Sprintf, etc
+//
+// %+v writes every error of this MultiError with its StackTrace, like Error does.
+//
+// %-v writes the errors on a single line, see SetCompactCauseSeparator.
+//
+// %#v writes the Go syntax of this MultiError, see GoString.
+//
+// implements fmt.Formatter
+func (me *MultiError) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			if me == nil || len(me.Errors) == 0 {
+				return
+			}
+			if len(me.Errors) > 1 {
+				_, _ = fmt.Fprintf(state, "%d errors:", len(me.Errors))
+			}
+			for index, err := range me.Errors {
+				if index > 0 || len(me.Errors) > 1 {
+					_, _ = io.WriteString(state, "\n")
+				}
+				_, _ = fmt.Fprintf(state, "%+v", err)
+			}
+			return
+		}
+		if state.Flag('-') {
+			var sb strings.Builder
+			writeCauseText(&sb, me, compactCauseSeparator.Load().(string), true)
+			_, _ = io.WriteString(state, sb.String())
+			return
+		}
+		if state.Flag('#') {
+			_, _ = io.WriteString(state, me.GoString())
+			return
+		}
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(state, me.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(state, "%q", me.Error())
+	}
+}
+
+// GoString returns the Go syntax of this MultiError
+//
+// implements fmt.GoStringer
+func (me *MultiError) GoString() string {
+	if me == nil {
+		return "(*errors.MultiError)(nil)"
+	}
+	var sb strings.Builder
+	_, _ = sb.WriteString("&errors.MultiError{Errors: []error{")
+	for index, err := range me.Errors {
+		if index > 0 {
+			_, _ = sb.WriteString(", ")
+		}
+		if gostringer, ok := err.(fmt.GoStringer); ok {
+			_, _ = sb.WriteString(gostringer.GoString())
+		} else {
+			_, _ = fmt.Fprintf(&sb, "%q", err.Error())
+		}
+	}
+	_, _ = sb.WriteString("}}")
+	return sb.String()
+}
+
 // IsEmpty returns true if this MultiError contains no errors
 func (me *MultiError) IsEmpty() bool {
 	return me == nil || len(me.Errors) == 0
@@ -44,6 +114,41 @@ func (me *MultiError) Append(errs ...error) {
 	}
 }
 
+// AppendWithStack appends new errors with the StackTrace of the caller
+//
+// Errors that are not an Error are wrapped like WithStack does,
+// Errors without a StackTrace get one, the others are appended as is.
+//
+// This tells where each error was collected, like in a batch loop:
+//
+//	for _, item := range items {
+//	  errs.AppendWithStack(process(item))
+//	}
+//
+// If an error is nil, it is not added
+func (me *MultiError) AppendWithStack(errs ...error) {
+	var stack StackTrace
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		final, ok := asError(err)
+		if !ok {
+			final = Error{Code: http.StatusInternalServerError, ID: "error.runtime", Cause: err}
+		} else if len(final.Stack) > 0 {
+			me.Errors = append(me.Errors, final)
+			continue
+		}
+		final = final.derive()
+		if stack == nil {
+			stack.capture(3) // skip extern.go, capture, this func
+		}
+		final.Stack = stack
+		final.enrich()
+		me.Errors = append(me.Errors, final)
+	}
+}
+
 // Is tells if this error matches the target.
 //
 // implements errors.Is interface (package "errors").
@@ -77,7 +182,10 @@ func (e MultiError) As(target interface{}) bool {
 	return false
 }
 
-// AsError returns this if it contains errors, nil otherwise
+// AsError returns this wrapped in MultipleErrors if it contains errors, nil otherwise
+//
+// The Code of the returned Error is the highest Code of the errors of this,
+// errors that are not an Error count as http.StatusInternalServerError.
 //
 // If this contains only one error, that error is returned.
 //
@@ -95,5 +203,25 @@ func (me *MultiError) AsError() error {
 		}
 		return WithStack(me.Errors[0])
 	}
-	return WithStack(me)
+	final := MultipleErrors.derive()
+	final.Code = me.highestCode()
+	final.Cause = me
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// highestCode returns the highest Code of the errors of this MultiError
+func (me *MultiError) highestCode() (code int) {
+	for _, err := range me.Errors {
+		current := http.StatusInternalServerError
+		var details Error
+		if As(err, &details) && details.Code != 0 {
+			current = details.Code
+		}
+		if current > code {
+			code = current
+		}
+	}
+	return
 }
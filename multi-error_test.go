@@ -1,7 +1,9 @@
 package errors_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"reflect"
 	"strings"
@@ -155,6 +157,14 @@ func (suite *MultiErrorSuite) TestShouldNotConvertToOtherErrors() {
 	suite.Assert().False(errors.As(errs.AsError(), &otherDetails), "should not be able to convert to os.PathError")
 }
 
+func (suite *MultiErrorSuite) TestCanAppendErrorPointerWithStack() {
+	errs := &errors.MultiError{}
+	errs.AppendWithStack(errors.NotFound.Clone())
+	suite.Require().Len(errs.Errors, 1)
+	suite.Assert().ErrorIs(errs.Errors[0], errors.NotFound)
+	suite.Assert().Equal(errors.NotFound.Code, errs.Errors[0].(errors.Error).Code, "a *Error should not become a runtime error")
+}
+
 func ExampleMultiError() {
 	var errs errors.MultiError
 
@@ -168,3 +178,86 @@ func ExampleMultiError() {
 	// this is the first error
 	// this is the second error
 }
+
+func (suite *MultiErrorSuite) TestCanFormat() {
+	requireStack(suite.T())
+	errs := &errors.MultiError{}
+	errs.Append(errors.ArgumentMissing.With("name"), fmt.Errorf("simple error"))
+
+	suite.Assert().Equal(errs.Error(), fmt.Sprintf("%v", errs))
+	suite.Assert().Equal(errs.Error(), fmt.Sprintf("%s", errs))
+	suite.Assert().Equal(fmt.Sprintf("%q", errs.Error()), fmt.Sprintf("%q", errs))
+	suite.Assert().Equal("2 errors: [Argument name is missing; simple error]", fmt.Sprintf("%-v", errs))
+
+	text := fmt.Sprintf("%+v", errs)
+	suite.Assert().True(strings.HasPrefix(text, "2 errors:\nArgument name is missing\n"), text)
+	suite.Assert().Contains(text, "(*MultiErrorSuite).TestCanFormat", "the stack of the members should be written")
+	suite.Assert().Contains(text, "\nsimple error")
+
+	text = fmt.Sprintf("%+v", errs.AsError())
+	suite.Assert().Contains(text, "Caused by: error.argument.missing", "the stacks of the members should be written")
+}
+
+func (suite *MultiErrorSuite) TestCanGoString() {
+	errs := &errors.MultiError{}
+	errs.Append(errors.Error{Code: 400, ID: "error.argument.missing", Text: "Argument %s is missing", What: "name"}, fmt.Errorf("simple error"))
+	expected := `&errors.MultiError{Errors: []error{errors.Error{Code: 400, ID: "error.argument.missing", Text: "Argument %s is missing", What: "name"}, "simple error"}}`
+	suite.Assert().Equal(expected, errs.GoString())
+	suite.Assert().Equal(expected, fmt.Sprintf("%#v", errs))
+
+	var none *errors.MultiError
+	suite.Assert().Equal("(*errors.MultiError)(nil)", none.GoString())
+}
+
+func (suite *MultiErrorSuite) TestCanAppendWithStack() {
+	requireStack(suite.T())
+	errs := &errors.MultiError{}
+	withStack := errors.NotFound.With("user")
+	errs.AppendWithStack(fmt.Errorf("simple error"), nil, errors.ArgumentMissing.WithoutStack(), withStack)
+	suite.Require().Len(errs.Errors, 3)
+
+	var details errors.Error
+	suite.Require().True(errors.As(errs.Errors[0], &details), "The first error should be an errors.Error")
+	suite.Assert().Equal("error.runtime", details.ID)
+	suite.Assert().Equal("simple error", details.Cause.Error())
+	suite.Require().NotEmpty(details.Stack)
+	suite.Assert().Contains(details.Stack[0].FuncName(), "TestCanAppendWithStack", "The stack should start at the caller")
+
+	suite.Require().True(errors.As(errs.Errors[1], &details), "The second error should be an errors.Error")
+	suite.Assert().Equal("error.argument.missing", details.ID)
+	suite.Require().NotEmpty(details.Stack)
+	suite.Assert().Contains(details.Stack[0].FuncName(), "TestCanAppendWithStack", "The stack should start at the caller")
+
+	suite.Assert().Equal(withStack, errs.Errors[2], "An error with a stack should be appended as is")
+}
+
+func (suite *MultiErrorSuite) TestCanConvertToMultipleErrors() {
+	requireStack(suite.T())
+	var errs errors.MultiError
+	errs.Append(errors.ArgumentMissing.With("name1"), errors.NotFound.With("user", "john"))
+	err := errs.AsError()
+	suite.Require().ErrorIs(err, errors.MultipleErrors)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("error.multiple", details.ID)
+	suite.Assert().Equal(http.StatusNotFound, details.Code, "The code should be the highest code of the errors")
+	suite.Require().NotEmpty(details.Stack)
+	suite.Assert().Contains(details.Stack[0].FuncName(), "TestCanConvertToMultipleErrors")
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().NoError(jerr)
+	suite.Assert().Contains(string(payload), `"id":"error.multiple"`)
+
+	errs.Append(fmt.Errorf("simple error"))
+	suite.Assert().True(errors.IsCode(errs.AsError(), http.StatusInternalServerError), "errors that are not an Error should count as 500")
+}
+
+func (suite *MultiErrorSuite) TestCanAppendWithStackWithFrozenSentinels() {
+	errors.FreezeSentinels(true)
+	defer errors.FreezeSentinels(false)
+
+	errs := &errors.MultiError{}
+	errs.AppendWithStack(errors.NotFound)
+	suite.Require().Len(errs.Errors, 1)
+	suite.Assert().NotPanics(func() { _ = errs.Errors[0].(errors.Error).WithField("key", "value") })
+}
@@ -0,0 +1,45 @@
+package errors
+
+import "strings"
+
+// Must returns the given value if err is nil, it panics otherwise
+//
+// The panic value is an Error annotated with a stack trace at the point Must was called,
+// FromRecover can convert it back to an error.
+//
+// Must is meant for initialization code, like:
+//
+//	var config = errors.Must(LoadConfig("config.yaml"))
+func Must[T any](value T, err error) T {
+	if err != nil {
+		panic(mustError(err))
+	}
+	return value
+}
+
+// MustNot panics if err is not nil
+//
+// The panic value is an Error annotated with a stack trace at the point MustNot was called,
+// FromRecover can convert it back to an error.
+func MustNot(err error) {
+	if err != nil {
+		panic(mustError(err))
+	}
+}
+
+// mustError annotates err with a stack trace that starts at the caller of Must or MustNot
+func mustError(err error) Error {
+	if pointer, ok := err.(*Error); ok && pointer != nil {
+		err = *pointer
+	}
+	final, ok := err.(Error)
+	if ok && len(final.Stack) > 0 {
+		return final
+	}
+	final = WithStack(err).(Error)
+	const self = "github.com/gildas/go-errors."
+	for len(final.Stack) > 0 && strings.HasPrefix(final.Stack[0].FuncName(), self) {
+		final.Stack = final.Stack[1:]
+	}
+	return final
+}
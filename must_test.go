@@ -0,0 +1,58 @@
+package errors_test
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanMust() {
+	suite.Assert().Equal(12, errors.Must(strconv.Atoi("12")))
+	suite.Assert().NotPanics(func() { errors.MustNot(nil) })
+}
+
+func (suite *ErrorsSuite) TestShouldPanicWithMust() {
+	requireStack(suite.T())
+	err := recoverFrom(func() { _ = errors.Must(strconv.Atoi("twelve")) })
+	suite.Require().NotNil(err)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Require().NotEmpty(details.Stack)
+	suite.Assert().Equal("(*ErrorsSuite).TestShouldPanicWithMust.func1", fmt.Sprintf("%n", details.Stack[0]))
+	suite.Assert().Contains(err.Error(), `strconv.Atoi: parsing "twelve": invalid syntax`)
+}
+
+func (suite *ErrorsSuite) TestShouldPanicWithMustNot() {
+	requireStack(suite.T())
+	err := recoverFrom(func() { errors.MustNot(errors.NotFound.With("user", "john")) })
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().Equal("(*ErrorsSuite).TestShouldPanicWithMustNot.func1", fmt.Sprintf("%n", err.(errors.Error).Stack[0]))
+}
+
+func (suite *ErrorsSuite) TestCanConvertRecoveredValues() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromRecover(nil))
+
+	err := recoverFrom(func() { panic("boom") })
+	suite.Assert().ErrorIs(err, errors.Panicked)
+	suite.Assert().Equal("Panic: boom", err.Error())
+	suite.Assert().Equal("boom", err.(errors.Error).Value)
+
+	failed := fmt.Errorf("failed")
+	err = recoverFrom(func() { panic(failed) })
+	suite.Assert().ErrorIs(err, failed)
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+
+	err = recoverFrom(func() { panic(errors.NotFound.Clone()) })
+	suite.Assert().ErrorIs(err, errors.NotFound)
+}
+
+func recoverFrom(f func()) (err error) {
+	defer func() {
+		err = errors.FromRecover(recover())
+	}()
+	f()
+	return nil
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strconv"
+	"syscall"
+)
+
+// FromNet converts an error from the net and crypto/tls packages into an Error
+//
+// The errors are mapped to the following sentinels:
+//
+//	*net.DNSError (not found)                 -> DNSNotFound
+//	TLS alerts, certificate errors            -> TLSHandshakeFailed
+//	ECONNREFUSED, ECONNRESET, EPIPE...        -> see FromErrno
+//	timeouts                                  -> Timeout (retryable)
+//
+// If err is nil, FromNet returns nil. Errors and *Error are returned as is.
+func FromNet(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	var final Error
+	var dnsError *net.DNSError
+	var errno syscall.Errno
+	var netError net.Error
+	attributes := map[string]interface{}{}
+
+	switch {
+	case As(err, &dnsError) && dnsError.IsNotFound:
+		final = DNSNotFound.derive()
+		final.What = dnsError.Name
+	case isTLSError(err):
+		final = TLSHandshakeFailed.derive()
+	case As(err, &errno) && isKnownErrno(errno):
+		final, _ = errnoSentinel(errno)
+	case As(err, &netError) && netError.Timeout():
+		final = Timeout.derive()
+		final.What = "Connection"
+		final.Retryable = true
+	default:
+		return WithStack(err)
+	}
+
+	if dnsError != nil {
+		attributes["host"] = dnsError.Name
+		if len(dnsError.Server) > 0 {
+			attributes["dns_server"] = dnsError.Server
+		}
+	}
+	var opError *net.OpError
+	if As(err, &opError) {
+		final.Operation = opError.Op
+		if len(opError.Net) > 0 {
+			attributes["network"] = opError.Net
+		}
+		if opError.Addr != nil {
+			host, port := splitAddress(opError.Addr.String())
+			attributes["host"] = host
+			if port != nil {
+				attributes["port"] = port
+			}
+		}
+	}
+	var hostnameError x509.HostnameError
+	if As(err, &hostnameError) {
+		attributes["host"] = hostnameError.Host
+	}
+	if len(attributes) > 0 {
+		final.Attributes = attributes
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// isTLSError tells if the given error comes from a TLS handshake
+func isTLSError(err error) bool {
+	var recordHeaderError tls.RecordHeaderError
+	var alertError tls.AlertError
+	var verificationError *tls.CertificateVerificationError
+	var unknownAuthorityError x509.UnknownAuthorityError
+	var hostnameError x509.HostnameError
+	var invalidError x509.CertificateInvalidError
+	if As(err, &recordHeaderError) ||
+		As(err, &alertError) ||
+		As(err, &verificationError) ||
+		As(err, &unknownAuthorityError) ||
+		As(err, &hostnameError) ||
+		As(err, &invalidError) {
+		return true
+	}
+	// crypto/tls reports the alerts sent by the peer as a *net.OpError with the Op "remote error"
+	var opError *net.OpError
+	return As(err, &opError) && opError.Op == "remote error"
+}
+
+// isKnownErrno tells if the given errno is mapped by FromErrno
+func isKnownErrno(errno syscall.Errno) bool {
+	_, ok := errnoSentinel(errno)
+	return ok
+}
+
+// splitAddress splits the given network address in its host and port
+//
+// The port is an int if it is numeric, nil if there is none.
+func splitAddress(address string) (string, interface{}) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, nil
+	}
+	if number, err := strconv.Atoi(port); err == nil {
+		return host, number
+	}
+	return host, port
+}
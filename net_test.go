@@ -0,0 +1,85 @@
+package errors_test
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertDNSErrors() {
+	requireStack(suite.T())
+	err := errors.FromNet(&url.Error{
+		Op:  "Get",
+		URL: "https://bogus.example.com/",
+		Err: &net.OpError{
+			Op:  "dial",
+			Net: "tcp",
+			Err: &net.DNSError{
+				Err:        "no such host",
+				Name:       "bogus.example.com",
+				Server:     "208.67.222.222:53",
+				IsNotFound: true,
+			},
+		},
+	})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.DNSNotFound)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Contains(details.Error(), "Host bogus.example.com Not Found")
+	suite.Assert().Equal("dial", details.Operation)
+	suite.Assert().Equal("bogus.example.com", details.Attributes["host"])
+	suite.Assert().Equal("208.67.222.222:53", details.Attributes["dns_server"])
+	suite.Assert().Equal("tcp", details.Attributes["network"])
+	suite.Assert().NotEmpty(details.Stack)
+}
+
+func (suite *ErrorsSuite) TestCanConvertConnectionErrors() {
+	suite.Assert().Nil(errors.FromNet(nil))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromNet(pointer), "a *Error should be returned as is")
+
+	err := errors.FromNet(&net.OpError{
+		Op:   "dial",
+		Net:  "tcp",
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5432},
+		Err:  os.NewSyscallError("connect", syscall.ECONNREFUSED),
+	})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ConnectionRefused)
+	details := err.(errors.Error)
+	suite.Assert().True(details.Retryable)
+	suite.Assert().Equal("10.0.0.1", details.Attributes["host"])
+	suite.Assert().Equal(5432, details.Attributes["port"])
+	suite.Assert().Equal("dial", details.Operation)
+
+	err = errors.FromNet(&net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded})
+	suite.Assert().ErrorIs(err, errors.Timeout)
+	suite.Assert().True(err.(errors.Error).Retryable)
+
+	err = errors.FromNet(fmt.Errorf("not a network error"))
+	suite.Assert().Equal("error.runtime", err.(errors.Error).ID)
+}
+
+func (suite *ErrorsSuite) TestCanConvertTLSErrors() {
+	err := errors.FromNet(&url.Error{
+		Op:  "Get",
+		URL: "https://example.com/",
+		Err: &net.OpError{
+			Op:  "remote error",
+			Net: "",
+			Err: fmt.Errorf("tls handshake failure"),
+		},
+	})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.TLSHandshakeFailed)
+
+	err = errors.FromNet(fmt.Errorf("handshake: %w", x509.HostnameError{Certificate: &x509.Certificate{}, Host: "example.com"}))
+	suite.Assert().ErrorIs(err, errors.TLSHandshakeFailed)
+	suite.Assert().Equal("example.com", err.(errors.Error).Attributes["host"])
+}
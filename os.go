@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// FromOS converts an error from the os and io/fs packages into an Error
+//
+// The errors are mapped to the following sentinels:
+//
+//	fs.ErrNotExist           -> FileNotFound (in the "error.notfound" category)
+//	fs.ErrPermission         -> FileAccessDenied (in the "error.permission.denied" category)
+//	fs.ErrExist              -> DuplicateFound
+//	os.ErrDeadlineExceeded   -> Timeout
+//	ENOSPC                   -> DiskFull
+//	ENAMETOOLONG, ENOTDIR    -> PathInvalid
+//
+// If err is nil, FromOS returns nil. Errors and *Error are returned as is.
+func FromOS(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	var final Error
+	switch {
+	case Is(err, fs.ErrNotExist):
+		final = FileNotFound.derive()
+	case Is(err, fs.ErrPermission):
+		final = FileAccessDenied.derive()
+	case Is(err, fs.ErrExist):
+		final = DuplicateFound.derive()
+		final.Value = "" // DuplicateFound renders "What Value Found"
+	case Is(err, os.ErrDeadlineExceeded):
+		final = Timeout.derive()
+	case Is(err, syscall.ENOSPC):
+		final = DiskFull.derive()
+	case Is(err, syscall.ENAMETOOLONG), Is(err, syscall.ENOTDIR):
+		final = PathInvalid.derive()
+	default:
+		return WithStack(err)
+	}
+	if path := osPath(err); len(path) > 0 {
+		final = final.WithPath(path)
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// osPath gives the path the given error is about, if any
+func osPath(err error) string {
+	var pathError *fs.PathError
+	if As(err, &pathError) {
+		return pathError.Path
+	}
+	var linkError *os.LinkError
+	if As(err, &linkError) {
+		return linkError.New
+	}
+	return ""
+}
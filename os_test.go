@@ -0,0 +1,78 @@
+package errors_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertOSErrors() {
+	requireStack(suite.T())
+	filename := filepath.Join(suite.T().TempDir(), "missing.txt")
+	_, err := os.Open(filename)
+	suite.Require().Error(err)
+
+	err = errors.FromOS(err)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().ErrorIs(err, errors.FileNotFound)
+	suite.Assert().True(errors.IsCategory(err, "error.notfound"))
+	suite.Assert().ErrorIs(err, fs.ErrNotExist)
+	var pathError *fs.PathError
+	suite.Assert().ErrorAs(err, &pathError)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal(filename, details.What)
+	suite.Assert().Equal(filename, details.Attributes["path"])
+	suite.Assert().Contains(details.Error(), "File "+filename+" Not Found")
+	suite.Assert().Equal(404, details.Code)
+	suite.Assert().NotEmpty(details.Stack)
+}
+
+func (suite *ErrorsSuite) TestCanConvertOSErrorsWithoutPath() {
+	suite.Assert().Nil(errors.FromOS(nil))
+
+	err := errors.FromOS(fs.ErrPermission)
+	suite.Assert().ErrorIs(err, errors.PermissionDenied)
+	suite.Assert().ErrorIs(err, errors.FileAccessDenied)
+	suite.Assert().True(errors.IsCategory(err, "error.permission.denied"))
+	suite.Assert().Equal(403, err.(errors.Error).Code)
+
+	err = errors.FromOS(&fs.PathError{Op: "mkdir", Path: "/tmp/data", Err: fs.ErrExist})
+	suite.Assert().ErrorIs(err, errors.DuplicateFound)
+	suite.Assert().Equal("/tmp/data", err.(errors.Error).What)
+
+	err = errors.FromOS(os.ErrDeadlineExceeded)
+	suite.Assert().ErrorIs(err, errors.Timeout)
+
+	err = errors.FromOS(fs.ErrClosed)
+	suite.Assert().ErrorIs(err, fs.ErrClosed)
+	suite.Assert().NotErrorIs(err, errors.NotFound)
+
+	original := errors.NotFound.With("user", "john")
+	suite.Assert().Equal(original, errors.FromOS(original))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromOS(pointer), "a *Error should be returned as is")
+}
+
+func (suite *ErrorsSuite) TestCanConvertStorageErrors() {
+	err := errors.FromOS(&fs.PathError{Op: "write", Path: "/var/data/dump.sql", Err: syscall.ENOSPC})
+	suite.Assert().ErrorIs(err, errors.DiskFull)
+	suite.Assert().Equal("/var/data/dump.sql", err.(errors.Error).Attributes["path"])
+
+	err = errors.FromOS(&fs.PathError{Op: "open", Path: "/etc/passwd/shadow", Err: syscall.ENOTDIR})
+	suite.Assert().ErrorIs(err, errors.PathInvalid)
+	suite.Assert().Contains(err.Error(), "Invalid path /etc/passwd/shadow")
+}
+
+func (suite *ErrorsSuite) TestCanCreateErrorWithPathAndSize() {
+	err := errors.ChecksumMismatch.WithPath("bucket/report.pdf").WithSize(1024)
+	suite.Assert().ErrorIs(err, errors.ChecksumMismatch)
+	suite.Assert().Equal("bucket/report.pdf", err.What)
+	suite.Assert().Equal("bucket/report.pdf", err.Attributes["path"])
+	suite.Assert().Equal(int64(1024), err.Attributes["size"])
+	suite.Assert().Empty(errors.ChecksumMismatch.Attributes, "the sentinel should not be modified")
+}
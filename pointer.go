@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"strings"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPointer builds a JSON Pointer (RFC 6901) from the given property names or array indexes
+//
+// The "~" and "/" characters of the names are escaped.
+//
+// Example:
+//
+//	errors.JSONPointer("spec", "containers", "0", "image") // "/spec/containers/0/image"
+func JSONPointer(segments ...string) string {
+	var pointer strings.Builder
+	for _, segment := range segments {
+		_, _ = pointer.WriteString("/")
+		_, _ = pointer.WriteString(pointerEscaper.Replace(segment))
+	}
+	return pointer.String()
+}
+
+// pathToPointer converts a field path, like: "items[3].price", into a JSON Pointer, like: "/items/3/price"
+func pathToPointer(path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' || r == ']' })
+	return JSONPointer(segments...)
+}
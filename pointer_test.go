@@ -0,0 +1,41 @@
+package errors_test
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanBuildJSONPointer() {
+	suite.Assert().Equal("/spec/replicas", errors.JSONPointer("spec", "replicas"))
+	suite.Assert().Equal("/spec/containers/0/image", errors.JSONPointer("spec", "containers", "0", "image"))
+	suite.Assert().Equal("/metadata/labels/app.kubernetes.io~1name/a~0b", errors.JSONPointer("metadata", "labels", "app.kubernetes.io/name", "a~b"))
+	suite.Assert().Equal("", errors.JSONPointer())
+}
+
+func (suite *ErrorsSuite) TestCanMarshalJSONPropertyMissingWithPointer() {
+	err := errors.JSONPropertyMissing.WithWhat("replicas").WithPointer(errors.JSONPointer("spec", "replicas"))
+	suite.Assert().Equal("/spec/replicas", err.Pointer)
+	suite.Assert().Equal("/spec/replicas", err.Fields()["pointer"])
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().NoError(jerr)
+	var decoded map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(payload, &decoded))
+	suite.Assert().Equal("/spec/replicas", decoded["pointer"])
+
+	var unmarshaled errors.Error
+	suite.Require().NoError(json.Unmarshal(payload, &unmarshaled))
+	suite.Assert().Equal("/spec/replicas", unmarshaled.Pointer)
+}
+
+func (suite *ErrorsSuite) TestShouldComputePointerOfFieldErrors() {
+	err := errors.ValidationFailed.ForField("items[3].price", "min", -1)
+	suite.Assert().Equal("/items/3/price", err.Pointer)
+
+	payload := []byte(`{"items": [{"price": "cheap"}]}`)
+	var order jsonOrder
+	details, ok := errors.FromJSON(json.Unmarshal(payload, &order), payload).(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("/items/0/price", details.Pointer)
+}
@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PrintOption configures how Fprint writes errors
+type PrintOption func(options *printOptions)
+
+type printOptions struct {
+	color bool
+}
+
+// WithColor tells Fprint to colorize its output with ANSI escape sequences, or not
+//
+// By default, the output is colorized when written to a terminal and the NO_COLOR environment variable is not set.
+func WithColor(color bool) PrintOption {
+	return func(options *printOptions) {
+		options.color = color
+	}
+}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiRed     = "\x1b[31m"
+	ansiYellow  = "\x1b[33m"
+	ansiCyan    = "\x1b[36m"
+	printIndent = "    "
+)
+
+// Fprint writes err, its causes, and their stack traces to w, for human readers like CLI users
+//
+// The IDs, messages, and stack frames can be colorized, see WithColor.
+// The frames of the Go standard library are dimmed, the others are highlighted.
+//
+// Fprint returns the number of bytes written and any write error.
+func Fprint(w io.Writer, err error, options ...PrintOption) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+	printer := printOptions{color: isTerminal(w)}
+	for _, option := range options {
+		option(&printer)
+	}
+	var sb strings.Builder
+
+	if value, ok := asError(err); ok {
+		err = value.bounded()
+	}
+	printer.writeError(&sb, err, "")
+	_, _ = sb.WriteString("\n")
+	return io.WriteString(w, sb.String())
+}
+
+// writeError writes err and its causes in the given builder, each line starting with indent
+func (printer printOptions) writeError(sb *strings.Builder, err error, indent string) {
+	if multi, ok := err.(*MultiError); ok {
+		count := 0
+		if multi != nil {
+			count = len(multi.Errors)
+		}
+		_, _ = sb.WriteString(printer.paint(ansiBold, strconv.Itoa(count)+" errors:"))
+		for _, member := range branches(multi) {
+			_, _ = sb.WriteString("\n")
+			_, _ = sb.WriteString(indent + printIndent)
+			printer.writeError(sb, member, indent+printIndent)
+		}
+		return
+	}
+	value, ok := asError(err)
+	if !ok {
+		_, _ = sb.WriteString(printer.paint(ansiRed, err.Error()))
+		return
+	}
+	if len(value.ID) > 0 {
+		_, _ = sb.WriteString(printer.paint(ansiCyan, value.ID))
+		_, _ = sb.WriteString(": ")
+	}
+	_, _ = sb.WriteString(printer.paint(ansiBold+ansiRed, value.message()))
+	if len(value.Hint) > 0 {
+		_, _ = sb.WriteString("\n" + indent)
+		_, _ = sb.WriteString(printer.paint(ansiBold, "Hint: "))
+		_, _ = sb.WriteString(value.Hint)
+	}
+	if len(value.DocURL) > 0 {
+		_, _ = sb.WriteString("\n" + indent)
+		_, _ = sb.WriteString(printer.paint(ansiBold, "see: "))
+		_, _ = sb.WriteString(value.DocURL)
+	}
+	for _, frame := range value.Stack.visible() {
+		color := ansiYellow
+		if frame.IsStandard() {
+			color = ansiDim
+		}
+		_, _ = sb.WriteString("\n" + indent + printIndent)
+		_, _ = sb.WriteString(printer.paint(color, "at "+frame.FuncName()+" ("+frame.Filepath()+":"+strconv.Itoa(frame.Line())+")"))
+	}
+	if value.Cause != nil {
+		_, _ = sb.WriteString("\n" + indent)
+		_, _ = sb.WriteString(printer.paint(ansiBold, "Caused by: "))
+		printer.writeError(sb, value.Cause, indent)
+	}
+}
+
+// paint surrounds the given text with the given ANSI color, if colors are enabled
+func (printer printOptions) paint(color, text string) string {
+	if !printer.color || len(text) == 0 {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// isTerminal tells if w is a terminal that accepts colors
+func isTerminal(w io.Writer) bool {
+	if _, found := os.LookupEnv("NO_COLOR"); found {
+		return false
+	}
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
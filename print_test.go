@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanPrint() {
+	requireStack(suite.T())
+	err := errors.CreationFailed.With("user").(errors.Error).
+		WithHint("check the database").
+		WithCause(errors.ArgumentMissing.With("name")).
+		WithCause(errors.NotFound.With("group", "admins"))
+	var output bytes.Buffer
+	written, perr := errors.Fprint(&output, err)
+	suite.Require().Nil(perr)
+	suite.Assert().Equal(output.Len(), written)
+
+	text := output.String()
+	suite.Assert().NotContains(text, "\x1b[", "Buffers are not terminals")
+	suite.Assert().True(strings.HasPrefix(text, "error.creation.failed: Failed Creating user\nHint: check the database\n    at "), text)
+	suite.Assert().Contains(text, "\nCaused by: 2 errors:\n    error.argument.missing: Argument name is missing\n        at ")
+	suite.Assert().Contains(text, "\n    error.notfound: group admins Not Found\n        at ")
+	suite.Assert().Contains(text, "TestCanPrint (")
+	suite.Assert().True(strings.HasSuffix(text, "\n"))
+}
+
+func (suite *ErrorsSuite) TestCanPrintWithColors() {
+	requireStack(suite.T())
+	var output bytes.Buffer
+	_, err := errors.Fprint(&output, errors.NotFound.With("user", "john"), errors.WithColor(true))
+	suite.Require().Nil(err)
+
+	text := output.String()
+	suite.Assert().True(strings.HasPrefix(text, "\x1b[36merror.notfound\x1b[0m: \x1b[1m\x1b[31muser john Not Found\x1b[0m"), text)
+	suite.Assert().Contains(text, "\x1b[33mat github.com/gildas/go-errors_test.(*ErrorsSuite).TestCanPrintWithColors (")
+	suite.Assert().Contains(text, "\x1b[2mat testing.tRunner (")
+}
+
+func (suite *ErrorsSuite) TestCanPrintNilError() {
+	var output bytes.Buffer
+	written, err := errors.Fprint(&output, nil)
+	suite.Require().Nil(err)
+	suite.Assert().Zero(written)
+}
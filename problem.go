@@ -0,0 +1,206 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// ProblemContentType is the Content-Type of the problem documents of RFC 9457
+const ProblemContentType = "application/problem+json"
+
+// Problem is a problem document as described in RFC 9457, like:
+//
+//	{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "User john Not Found", "code": "error.notfound"}
+type Problem struct {
+	// Type is the URI of the problem type, "about:blank" by default
+	Type string `json:"type"`
+	// Title is the short summary of the problem type
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code
+	Status int `json:"status,omitempty"`
+	// Detail is the explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is the URI of this occurrence of the problem, like the path of the request
+	Instance string `json:"instance,omitempty"`
+	// Code is the ID of the Error, like: "error.notfound"
+	Code string `json:"code,omitempty"`
+	// CorrelationID is the Correlation ID of the Error, if any
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Source tells which part of the request caused the problem, if known
+	Source *ProblemSource `json:"source,omitempty"`
+	// Errors contains the problems that caused this one, like the fields that failed a validation
+	Errors []Problem `json:"errors,omitempty"`
+	// Warnings contains the non-fatal errors of the request, see WarningsMiddleware
+	Warnings []Problem `json:"warnings,omitempty"`
+}
+
+// ProblemSource tells which part of the request caused a Problem
+type ProblemSource struct {
+	// Pointer is the JSON Pointer (RFC 6901) of the property of the request body, like: "/user/email"
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// ProblemType overrides how the errors of a sentinel are rendered as a Problem
+type ProblemType struct {
+	// URI is the Type of the Problem
+	URI string
+	// Title is the Title of the Problem
+	Title string
+}
+
+var (
+	problemTypes     = map[string]ProblemType{}
+	problemTypesLock sync.RWMutex
+)
+
+// RegisterProblemType tells ToProblem to render the errors with the ID of the given sentinel with the given ProblemType
+//
+// If a ProblemType was already registered for that sentinel, it is replaced.
+func RegisterProblemType(sentinel Error, problemType ProblemType) {
+	problemTypesLock.Lock()
+	defer problemTypesLock.Unlock()
+	problemTypes[sentinel.ID] = problemType
+}
+
+// UnregisterProblemType removes the ProblemType registered for the given sentinel
+func UnregisterProblemType(sentinel Error) {
+	problemTypesLock.Lock()
+	defer problemTypesLock.Unlock()
+	delete(problemTypes, sentinel.ID)
+}
+
+// problemContextKey is the key of the route's ProblemType in a context.Context, see ProblemMiddleware
+type problemContextKey struct{}
+
+// ProblemMiddleware returns a middleware that makes WriteError, and therefore HandlerFunc, write problem documents
+//
+// The given ProblemType, indexed by sentinel ID, override the registered ones for the routes served by the middleware.
+//
+// Example:
+//
+//	problems := errors.ProblemMiddleware(map[string]errors.ProblemType{
+//	  errors.NotFound.ID: {URI: "https://acme.com/problems/user-not-found", Title: "User Not Found"},
+//	})
+//	mux.Handle("/users/", problems(errors.HandlerFunc(getUser)))
+func ProblemMiddleware(types map[string]ProblemType) func(http.Handler) http.Handler {
+	routeTypes := make(map[string]ProblemType, len(types))
+	for id, problemType := range types {
+		routeTypes[id] = problemType
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), problemContextKey{}, routeTypes)))
+		})
+	}
+}
+
+// WriteProblem writes the given error to the client as a problem document, see ToProblem
+//
+// Like WriteError, the request-scoped values are copied into the error, and the RateLimit headers are written.
+//
+// The Instance of the Problem is the path of the request.
+//
+// If err is nil, WriteProblem writes nothing.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+	var routeTypes map[string]ProblemType
+	if r != nil {
+		err = WithContext(r.Context(), err)
+		routeTypes, _ = r.Context().Value(problemContextKey{}).(map[string]ProblemType)
+	}
+	writeProblem(w, r, err, routeTypes)
+}
+
+// writeProblem writes the given error as a problem document with the given route's ProblemType
+func writeProblem(w http.ResponseWriter, r *http.Request, err error, routeTypes map[string]ProblemType) {
+	writeRateLimit(w, err)
+	problem := toProblem(err, routeTypes)
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+	for _, warning := range warningsOf(r).sanitized() {
+		problem.Warnings = append(problem.Warnings, warning.toProblem(routeTypes))
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// ToProblem converts err into a Problem
+//
+// The error is sanitized first, see Sanitize. Its ID gives the Code, its DocURL the Type, its message the Detail,
+// and its Pointer the Source. The Title is the text of the HTTP status code.
+//
+// The Type and Title can be overridden per sentinel with RegisterProblemType.
+//
+// The FieldError of ValidationErrors and the Error causes become the Errors of the Problem.
+//
+// If err is nil, ToProblem returns an empty Problem.
+func ToProblem(err error) Problem {
+	if err == nil {
+		return Problem{}
+	}
+	return toProblem(err, nil)
+}
+
+// toProblem converts err into a Problem, the route's ProblemType take precedence over the registered ones
+func toProblem(err error, routeTypes map[string]ProblemType) Problem {
+	sanitized := Sanitize(err).(Error)
+	problem := sanitized.toProblem(routeTypes)
+	problem.CorrelationID = sanitized.CorrelationID
+	var validation *ValidationErrors
+	if As(err, &validation) {
+		for _, fieldError := range validation.FieldErrors() {
+			child := Problem{Detail: fieldError.Error()}
+			if len(fieldError.Pointer) > 0 {
+				child.Source = &ProblemSource{Pointer: fieldError.Pointer}
+			}
+			problem.Errors = append(problem.Errors, child)
+		}
+	} else {
+		for _, cause := range sanitized.Causes() {
+			if cause, ok := asError(cause); ok {
+				problem.Errors = append(problem.Errors, cause.toProblem(routeTypes))
+			}
+		}
+	}
+	return problem
+}
+
+// toProblem converts this Error into a Problem, without its causes
+func (e Error) toProblem(routeTypes map[string]ProblemType) Problem {
+	status := statusCode(e.Code)
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: e.message(),
+		Code:   e.ID,
+	}
+	if len(e.DocURL) > 0 {
+		problem.Type = e.DocURL
+	}
+	if len(e.Pointer) > 0 {
+		problem.Source = &ProblemSource{Pointer: e.Pointer}
+	}
+	problemType, found := routeTypes[e.ID]
+	if !found {
+		problemTypesLock.RLock()
+		problemType, found = problemTypes[e.ID]
+		problemTypesLock.RUnlock()
+	}
+	if found {
+		if len(problemType.URI) > 0 {
+			problem.Type = problemType.URI
+		}
+		if len(problemType.Title) > 0 {
+			problem.Title = problemType.Title
+		}
+	}
+	return problem
+}
@@ -0,0 +1,73 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertToProblem() {
+	problem := errors.ToProblem(errors.JSONPropertyMissing.WithPointer(errors.JSONPointer("spec", "replicas")).With("replicas"))
+	suite.Assert().Equal("about:blank", problem.Type)
+	suite.Assert().Equal("Bad Request", problem.Title)
+	suite.Assert().Equal(http.StatusBadRequest, problem.Status)
+	suite.Assert().Equal("JSON data is missing property replicas", problem.Detail)
+	suite.Assert().Equal("error.json.property.missing", problem.Code)
+	suite.Require().NotNil(problem.Source)
+	suite.Assert().Equal("/spec/replicas", problem.Source.Pointer)
+
+	payload, err := json.Marshal(problem)
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(payload), `"source":{"pointer":"/spec/replicas"}`)
+
+	suite.Assert().Equal(errors.Problem{}, errors.ToProblem(nil))
+}
+
+func (suite *ErrorsSuite) TestCanConvertValidationErrorsToProblem() {
+	errs := &errors.ValidationErrors{}
+	errs.Add("user.email", "format", "john")
+	errs.Add("items[3].price", "min", -1)
+	problem := errors.ToProblem(errs.AsError())
+	suite.Assert().Equal(http.StatusUnprocessableEntity, problem.Status)
+	suite.Assert().Equal("error.validation.failed", problem.Code)
+	suite.Require().Len(problem.Errors, 2)
+	suite.Require().NotNil(problem.Errors[0].Source)
+	suite.Assert().Equal("/user/email", problem.Errors[0].Source.Pointer)
+	suite.Require().NotNil(problem.Errors[1].Source)
+	suite.Assert().Equal("/items/3/price", problem.Errors[1].Source.Pointer)
+}
+
+func (suite *ErrorsSuite) TestCanOverrideProblemType() {
+	sentinel := errors.NewSentinel(http.StatusConflict, "error.test.problem.conflict", "Conflict on %s")
+	errors.RegisterProblemType(sentinel, errors.ProblemType{URI: "https://acme.com/problems/conflict", Title: "Resource Conflict"})
+	defer errors.UnregisterProblemType(sentinel)
+
+	problem := errors.ToProblem(sentinel.With("user"))
+	suite.Assert().Equal("https://acme.com/problems/conflict", problem.Type)
+	suite.Assert().Equal("Resource Conflict", problem.Title)
+
+	handler := errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return sentinel.With("user")
+	})
+	route := errors.ProblemMiddleware(map[string]errors.ProblemType{
+		sentinel.ID: {URI: "https://acme.com/problems/user-conflict"},
+	})(handler)
+
+	recorder := httptest.NewRecorder()
+	route.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/users/john", nil))
+	suite.Assert().Equal(http.StatusConflict, recorder.Code)
+	suite.Assert().Equal(errors.ProblemContentType, recorder.Header().Get("Content-Type"))
+
+	var payload errors.Problem
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &payload))
+	suite.Assert().Equal("https://acme.com/problems/user-conflict", payload.Type, "the route should override the registered type")
+	suite.Assert().Equal("Conflict", payload.Title, "the route override has no title")
+	suite.Assert().Equal("/users/john", payload.Instance)
+	suite.Assert().Equal("Conflict on user", payload.Detail)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/users/john", nil))
+	suite.Assert().Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"), "without the middleware, the error should be plain JSON")
+}
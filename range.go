@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// Range describes the values that are allowed, like the bounds of an argument
+//
+// A nil Min or Max means the range is open on that side.
+type Range struct {
+	// Min is the smallest allowed value, if any
+	Min interface{} `json:"min,omitempty"`
+	// Max is the largest allowed value, if any
+	Max interface{} `json:"max,omitempty"`
+}
+
+// WithRange creates a new Error from a given Error with the range of the allowed values
+//
+// The range is rendered in the message, like:
+//
+//	Argument page is out of range (value: 500, allowed: 1..100)
+//
+// Example:
+//
+//	return errors.ArgumentOutOfRange.WithRange(1, 100).With("page", page)
+func (e Error) WithRange(min, max interface{}) Error {
+	final := e.derive()
+	final.Range = &Range{Min: min, Max: max}
+	return final
+}
+
+// String returns the string version of this Range, like: "1..100"
+//
+// implements fmt.Stringer
+func (allowed Range) String() string {
+	var min, max string
+	if allowed.Min != nil {
+		min = fmt.Sprintf("%v", allowed.Min)
+	}
+	if allowed.Max != nil {
+		max = fmt.Sprintf("%v", allowed.Max)
+	}
+	return min + ".." + max
+}
@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCreateArgumentOutOfRange() {
+	err := errors.ArgumentOutOfRange.With("page", 500, 1, 100)
+	suite.Assert().ErrorIs(err, errors.ArgumentOutOfRange)
+	suite.Assert().Equal("Argument page is out of range (value: 500, allowed: 1..100)", err.Error())
+	var details errors.Error
+	suite.Require().ErrorAs(err, &details)
+	suite.Require().NotNil(details.Range)
+	suite.Assert().Equal(1, details.Range.Min)
+	suite.Assert().Equal(100, details.Range.Max)
+	suite.Assert().Nil(errors.ArgumentOutOfRange.Range, "the sentinel should not be modified")
+
+	payload, jerr := json.Marshal(details)
+	suite.Require().NoError(jerr)
+	suite.Assert().Contains(string(payload), `"range":{"min":1,"max":100}`)
+	var decoded errors.Error
+	suite.Require().NoError(json.Unmarshal(payload, &decoded))
+	suite.Require().NotNil(decoded.Range)
+	suite.Assert().Equal("1..100", decoded.Range.String())
+}
+
+func (suite *ErrorsSuite) TestCanCreateErrorWithRange() {
+	err := errors.ArgumentOutOfRange.WithRange(0, nil).With("offset", -1)
+	suite.Assert().Equal("Argument offset is out of range (value: -1, allowed: 0..)", err.Error())
+
+	err = errors.Missing.WithRange("a", "z").With("letter")
+	suite.Assert().Equal("letter is missing (allowed: a..z)", err.Error())
+
+	err = errors.ArgumentInvalid.With("name", "value")
+	suite.Assert().Nil(err.(errors.Error).Range, "two values should not make a range")
+}
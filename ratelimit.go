@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit describes a limit that was exceeded, like a rate limit or a quota
+type RateLimit struct {
+	// Limit is the maximum number of requests allowed in the current window
+	Limit int `json:"limit"`
+	// Remaining is the number of requests left in the current window
+	Remaining int `json:"remaining"`
+	// Reset is the time when the current window ends, if known
+	Reset time.Time `json:"reset,omitempty"`
+}
+
+// WithLimit creates a new Error from a given Error with the limit that was exceeded, typically RateLimitExceeded
+//
+// The limit is rendered in the message, like:
+//
+//	Rate limit exceeded for /api/users (limit: 100, remaining: 0, reset: 2024-05-01T12:00:00Z)
+//
+// and in HTTP headers, see RateLimit.Header.
+//
+// Example:
+//
+//	return errors.RateLimitExceeded.WithWhat("/api/users").WithLimit(100, 0, window.End)
+func (e Error) WithLimit(limit, remaining int, reset time.Time) Error {
+	final := e.derive()
+	final.RateLimit = &RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+	return final
+}
+
+// String returns the string version of this RateLimit, like: "limit: 100, remaining: 0, reset: 2024-05-01T12:00:00Z"
+//
+// implements fmt.Stringer
+func (limit RateLimit) String() string {
+	var sb strings.Builder
+	_, _ = sb.WriteString("limit: ")
+	_, _ = sb.WriteString(strconv.Itoa(limit.Limit))
+	_, _ = sb.WriteString(", remaining: ")
+	_, _ = sb.WriteString(strconv.Itoa(limit.Remaining))
+	if !limit.Reset.IsZero() {
+		_, _ = sb.WriteString(", reset: ")
+		_, _ = sb.WriteString(limit.Reset.UTC().Format(time.RFC3339))
+	}
+	return sb.String()
+}
+
+// RetryAfter returns how long clients should wait before retrying, rounded up to the second
+//
+// If the Reset is unknown or in the past, RetryAfter returns 0.
+func (limit RateLimit) RetryAfter() time.Duration {
+	if limit.Reset.IsZero() {
+		return 0
+	}
+	wait := time.Until(limit.Reset)
+	if wait <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(wait.Seconds())) * time.Second
+}
+
+// Header returns the HTTP headers of this RateLimit
+//
+// The headers are X-RateLimit-Limit, X-RateLimit-Remaining, and, if the Reset is known,
+// X-RateLimit-Reset (in seconds since the Unix epoch) and Retry-After (in seconds).
+func (limit RateLimit) Header() http.Header {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", strconv.Itoa(limit.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+	if !limit.Reset.IsZero() {
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(limit.Reset.Unix(), 10))
+		header.Set("Retry-After", strconv.Itoa(int(limit.RetryAfter()/time.Second)))
+	}
+	return header
+}
@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCreateErrorWithLimit() {
+	reset := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := errors.RateLimitExceeded.WithWhat("/api/users").WithLimit(100, 0, reset)
+	suite.Assert().Equal("Rate limit exceeded for /api/users (limit: 100, remaining: 0, reset: 2024-05-01T12:00:00Z)", err.Error())
+	suite.Assert().ErrorIs(err, errors.RateLimitExceeded)
+	suite.Assert().True(err.Retryable)
+	suite.Require().NotNil(err.RateLimit)
+	suite.Assert().Equal(100, err.RateLimit.Limit)
+	suite.Assert().Equal(err.RateLimit, err.Fields()["rate_limit"])
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().NoError(jerr)
+	var decoded errors.Error
+	suite.Require().NoError(json.Unmarshal(payload, &decoded))
+	suite.Require().NotNil(decoded.RateLimit)
+	suite.Assert().Equal(errors.RateLimit{Limit: 100, Remaining: 0, Reset: reset}, *decoded.RateLimit)
+
+	err = errors.QuotaExceeded.WithWhat("emails").WithLimit(1000, 0, time.Time{})
+	suite.Assert().Equal("Quota exceeded for emails (limit: 1000, remaining: 0)", err.Error())
+	suite.Assert().False(err.Retryable)
+	suite.Assert().True(errors.ConcurrencyLimitExceeded.Retryable)
+}
+
+func (suite *ErrorsSuite) TestCanGetRateLimitHeaders() {
+	reset := time.Now().Add(90 * time.Second)
+	limit := errors.RateLimit{Limit: 10, Remaining: 0, Reset: reset}
+	header := limit.Header()
+	suite.Assert().Equal("10", header.Get("X-RateLimit-Limit"))
+	suite.Assert().Equal("0", header.Get("X-RateLimit-Remaining"))
+	suite.Assert().NotEmpty(header.Get("X-RateLimit-Reset"))
+	suite.Assert().Contains([]string{"90", "91"}, header.Get("Retry-After"))
+
+	header = errors.RateLimit{Limit: 10, Remaining: 3}.Header()
+	suite.Assert().Empty(header.Get("Retry-After"))
+	suite.Assert().Equal(time.Duration(0), errors.RateLimit{Reset: time.Now().Add(-time.Minute)}.RetryAfter())
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// FromRecover converts the value returned by recover() into an error
+//
+// If the value is an Error, like the ones Must and MustNot panic with, it is returned as is.
+// If the value is another error, it is annotated with a stack trace.
+// Otherwise, FromRecover returns a Panicked error with the value.
+//
+// If the value is nil, FromRecover returns nil.
+//
+// Example:
+//
+//	defer func() {
+//	  if err := errors.FromRecover(recover()); err != nil {
+//	    log.Printf("recovered: %+v", err)
+//	  }
+//	}()
+func FromRecover(recovered interface{}) error {
+	switch actual := recovered.(type) {
+	case nil:
+		return nil
+	case Error:
+		return actual
+	case *Error:
+		if actual == nil {
+			return nil
+		}
+		return *actual
+	case error:
+		return WithStack(actual)
+	}
+	final := Panicked.derive()
+	final.What = fmt.Sprint(recovered)
+	final.Value = recovered
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// Panicked is used when a panic was recovered with a value that is not an error, see FromRecover.
+var Panicked = NewSentinel(http.StatusInternalServerError, "error.panic", "Panic: %s")
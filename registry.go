@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"iter"
+	"sync"
+)
+
+var (
+	registry     = map[string]int{}
+	registered   = []Error{}
+	registryLock sync.RWMutex
+)
+
+// register adds the given sentinel to the registry
+//
+// If a sentinel with the same ID is already registered, it is kept.
+func register(sentinel Error) {
+	if len(sentinel.ID) == 0 {
+		return
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, found := registry[sentinel.ID]; found {
+		return
+	}
+	registry[sentinel.ID] = len(registered)
+	registered = append(registered, sentinel)
+}
+
+// Lookup finds the sentinel registered with the given ID
+//
+// Sentinels are registered by NewSentinel.
+//
+// Example:
+//
+//	if sentinel, found := errors.Lookup("error.argument.invalid"); found {
+//	  // do something with sentinel
+//	}
+func Lookup(id string) (Error, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	if index, found := registry[id]; found {
+		return registered[index], true
+	}
+	return Error{}, false
+}
+
+// Sentinels iterates over all the registered sentinels in their registration order
+//
+// Example:
+//
+//	for sentinel := range errors.Sentinels() {
+//	  fmt.Println(sentinel.ID)
+//	}
+func Sentinels() iter.Seq[Error] {
+	registryLock.RLock()
+	sentinels := make([]Error, len(registered))
+	copy(sentinels, registered)
+	registryLock.RUnlock()
+
+	return func(yield func(Error) bool) {
+		for _, sentinel := range sentinels {
+			if !yield(sentinel) {
+				return
+			}
+		}
+	}
+}
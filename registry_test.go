@@ -0,0 +1,51 @@
+package errors_test
+
+import "github.com/gildas/go-errors"
+
+func (suite *ErrorsSuite) TestCanLookupSentinel() {
+	sentinel, found := errors.Lookup("error.argument.invalid")
+	suite.Require().True(found, "ArgumentInvalid should be registered")
+	suite.Assert().Equal(errors.ArgumentInvalid, sentinel)
+
+	custom := errors.NewSentinel(499, "error.test.registry.lookup", "Test Lookup")
+	sentinel, found = errors.Lookup("error.test.registry.lookup")
+	suite.Require().True(found, "the custom sentinel should be registered")
+	suite.Assert().Equal(custom, sentinel)
+
+	_, found = errors.Lookup("error.test.registry.unknown")
+	suite.Assert().False(found, "unknown sentinels should not be found")
+}
+
+func (suite *ErrorsSuite) TestShouldKeepFirstRegisteredSentinel() {
+	first := errors.NewSentinel(499, "error.test.registry.duplicate", "First")
+	_ = errors.NewSentinel(498, "error.test.registry.duplicate", "Second")
+	sentinel, found := errors.Lookup("error.test.registry.duplicate")
+	suite.Require().True(found)
+	suite.Assert().Equal(first, sentinel)
+}
+
+func (suite *ErrorsSuite) TestShouldNotRegisterDynamicHTTPErrors() {
+	_ = errors.FromHTTPStatusCode(599)
+	_, found := errors.Lookup("error.http.599")
+	suite.Assert().False(found, "dynamic HTTP errors should not be registered")
+}
+
+func (suite *ErrorsSuite) TestCanIterateOverSentinels() {
+	ids := map[string]bool{}
+	for sentinel := range errors.Sentinels() {
+		suite.Assert().NotEmpty(sentinel.ID)
+		suite.Assert().False(ids[sentinel.ID], "sentinel %s should be listed once", sentinel.ID)
+		ids[sentinel.ID] = true
+	}
+	suite.Assert().True(ids[errors.NotFound.ID])
+	suite.Assert().True(ids[errors.HTTPBadGateway.ID])
+
+	count := 0
+	for range errors.Sentinels() {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	suite.Assert().Equal(3, count)
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var stackSampling atomic.Int64
+
+// samplingCounters counts the errors created by each sentinel at each place, see SetStackSampling
+var samplingCounters sync.Map
+
+// samplingKey identifies the errors of a sentinel created at the same place
+type samplingKey struct {
+	id string
+	pc uintptr
+}
+
+// SetStackSampling captures the StackTrace of only 1 in every rate errors created with the same ID at the same place
+//
+// The other errors get an empty StackTrace, which removes the cost of the capture from the paths
+// that produce many errors while keeping some traces to diagnose them.
+// The first error created at a given place always gets its StackTrace.
+//
+// A rate of 1 or less means every error gets its StackTrace, which is the default.
+//
+// Sentinels can have their own rate, see SampleStacks.
+func SetStackSampling(rate int) {
+	stackSampling.Store(int64(rate))
+}
+
+// SampleStacks sets the stack sampling rate of a sentinel, overriding the one set with SetStackSampling
+//
+// A rate of 1 captures the StackTrace of every error created with this sentinel.
+//
+// Example:
+//
+//	var CacheMiss = errors.NewSentinel(http.StatusNotFound, "error.cache.miss", "Cache miss for %s", errors.SampleStacks(1000))
+func SampleStacks(rate int) SentinelOption {
+	return func(sentinel *Error) {
+		sentinel.stackSampling = max(rate, 1)
+	}
+}
+
+// initializeStack records the StackTrace of this Error at the point the Error func was called, if it is sampled
+func (e *Error) initializeStack() {
+	rate := int64(e.stackSampling)
+	if rate == 0 {
+		rate = stackSampling.Load()
+	}
+	if rate > 1 && !sampled(e.ID, rate) {
+		e.Stack = StackTrace{}
+		return
+	}
+	e.Stack.capture(4) // skip extern.go, capture, this func, Error.func
+}
+
+// sampled tells if the StackTrace of an Error with the given ID should be captured with the given rate
+func sampled(id string, rate int64) bool {
+	var counters [1]uintptr
+	runtime.Callers(4, counters[:]) // skip extern.go, this func, initializeStack, Error.func
+	key := samplingKey{id: id, pc: counters[0]}
+	counter, found := samplingCounters.Load(key)
+	if !found {
+		counter, _ = samplingCounters.LoadOrStore(key, &atomic.Int64{})
+	}
+	return (counter.(*atomic.Int64).Add(1)-1)%rate == 0
+}
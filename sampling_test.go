@@ -0,0 +1,44 @@
+package errors_test
+
+import (
+	"net/http"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanSampleStacks() {
+	requireStack(suite.T())
+	defer errors.SetStackSampling(0)
+	errors.SetStackSampling(10)
+	captured := 0
+	for attempt := 0; attempt < 100; attempt++ {
+		if err := errors.NotFound.With("user", "john").(errors.Error); len(err.Stack) > 0 {
+			captured++
+		}
+	}
+	suite.Assert().Equal(10, captured)
+
+	errors.SetStackSampling(0)
+	for attempt := 0; attempt < 10; attempt++ {
+		suite.Assert().NotEmpty(errors.NotFound.With("user", "john").(errors.Error).Stack)
+	}
+}
+
+func (suite *ErrorsSuite) TestCanSampleStacksPerSentinel() {
+	requireStack(suite.T())
+	sentinel := errors.NewSentinel(http.StatusNotFound, "error.test.cache.miss", "Cache miss for %s", errors.SampleStacks(50))
+	captured := 0
+	for attempt := 0; attempt < 100; attempt++ {
+		if err := sentinel.With("key").(errors.Error); len(err.Stack) > 0 {
+			captured++
+		}
+	}
+	suite.Assert().Equal(2, captured)
+
+	defer errors.SetStackSampling(0)
+	errors.SetStackSampling(1000)
+	always := errors.NewSentinel(http.StatusNotFound, "error.test.always", "Always %s", errors.SampleStacks(1))
+	for attempt := 0; attempt < 10; attempt++ {
+		suite.Assert().NotEmpty(always.With("key").(errors.Error).Stack)
+	}
+}
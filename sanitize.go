@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var (
+	publicAttributes     = map[string]bool{}
+	publicAttributesLock sync.RWMutex
+)
+
+// RegisterPublicAttributes tells Sanitize to keep the attributes with the given keys
+//
+// By default, all attributes are considered internal.
+func RegisterPublicAttributes(keys ...string) {
+	publicAttributesLock.Lock()
+	defer publicAttributesLock.Unlock()
+	for _, key := range keys {
+		publicAttributes[key] = true
+	}
+}
+
+// UnregisterPublicAttributes tells Sanitize to remove the attributes with the given keys
+func UnregisterPublicAttributes(keys ...string) {
+	publicAttributesLock.Lock()
+	defer publicAttributesLock.Unlock()
+	for _, key := range keys {
+		delete(publicAttributes, key)
+	}
+}
+
+// Sanitize returns a copy of the given error that is safe to send to external clients.
+//
+// The copy has no stack trace, no origin, no operation, no component, and no internal attributes.
+//
+// The Hint is kept, unless the Code is 5xx.
+//
+// Errors with a 5xx Code have their Text replaced by a generic message with the Correlation ID, if any,
+// and lose their What, Value, URL and Cause.
+//
+// Causes that are not Error are removed, as their text may contain internal information.
+//
+// If err is not an Error, it is replaced by a generic runtime error.
+//
+// Finally, the Redactors of the Config of err are applied, see ConfigOf.
+//
+// The original error should be logged, while the sanitized error should be the only one serialized to clients.
+//
+// If err is nil, Sanitize returns nil.
+func Sanitize(err error) error {
+	if err == nil {
+		return nil
+	}
+	details, ok := asError(err)
+	if !ok {
+		details = Error{Code: http.StatusInternalServerError, ID: "error.runtime"}
+	}
+	final := details.sanitize()
+	for _, redactor := range details.redactors() {
+		final = redactor(final)
+	}
+	return final
+}
+
+// sanitize returns an external-safe copy of this Error
+func (e Error) sanitize() Error {
+	final := Error{
+		Code:          e.Code,
+		ID:            e.ID,
+		Text:          e.Text,
+		What:          e.What,
+		Value:         e.Value,
+		Pointer:       e.Pointer,
+		URL:           e.URL,
+		Retryable:     e.Retryable,
+		DocURL:        e.DocURL,
+		CorrelationID: e.CorrelationID,
+	}
+	if final.Code >= http.StatusInternalServerError {
+		var text strings.Builder
+		_, _ = text.WriteString(http.StatusText(final.Code))
+		if text.Len() == 0 {
+			_, _ = text.WriteString(http.StatusText(http.StatusInternalServerError))
+		}
+		if len(final.CorrelationID) > 0 {
+			_, _ = text.WriteString(" (ref: ")
+			_, _ = text.WriteString(strings.ReplaceAll(final.CorrelationID, "%", "%%"))
+			_, _ = text.WriteString(")")
+		}
+		final.Text = text.String()
+		final.What = ""
+		final.Value = nil
+		final.URL = ""
+		return final
+	}
+	final.Hint = e.Hint
+	if len(e.Attributes) > 0 {
+		publicAttributesLock.RLock()
+		for key, value := range e.Attributes {
+			if publicAttributes[key] {
+				if final.Attributes == nil {
+					final.Attributes = map[string]interface{}{}
+				}
+				final.Attributes[key] = value
+			}
+		}
+		publicAttributesLock.RUnlock()
+	}
+	for _, cause := range e.Causes() {
+		if cause, ok := asError(cause); ok {
+			final = final.WithCause(cause.sanitize())
+		}
+	}
+	return final
+}
@@ -0,0 +1,69 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanSanitizeError() {
+	errors.RegisterPublicAttributes("field")
+	defer errors.UnregisterPublicAttributes("field")
+
+	err := errors.WrapErrors(
+		errors.ArgumentInvalid.WithCorrelationID("1234").WithOperation("db.query").WithFields(map[string]interface{}{"field": "email", "secret": "s3cr3t"}).With("email", "bogus"),
+		errors.ArgumentMissing.With("name"),
+		fmt.Errorf("internal database details"),
+	)
+	sanitized := errors.Sanitize(err)
+	suite.Require().NotNil(sanitized)
+	suite.Assert().ErrorIs(sanitized, errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(sanitized, errors.ArgumentMissing)
+
+	details, ok := sanitized.(errors.Error)
+	suite.Require().True(ok, "sanitized should be an errors.Error")
+	suite.Assert().Empty(details.Stack)
+	suite.Assert().Empty(details.Operation)
+	suite.Assert().Equal("1234", details.CorrelationID)
+	suite.Assert().Equal(map[string]interface{}{"field": "email"}, details.Attributes)
+	suite.Assert().Equal("Argument email is invalid (value: bogus)\nCaused by:\n\tArgument name is missing", sanitized.Error())
+
+	cause, ok := details.Cause.(errors.Error)
+	suite.Require().True(ok, "cause should be an errors.Error")
+	suite.Assert().Empty(cause.Stack)
+	suite.Assert().Nil(cause.Cause, "simple errors should be removed")
+
+	suite.Assert().Contains(err.Error(), "internal database details", "original error should not be modified")
+}
+
+func (suite *ErrorsSuite) TestCanSanitizeInternalError() {
+	err := errors.CreationFailed.WithCorrelationID("1234").WithField("secret", "s3cr3t").Wrap(fmt.Errorf("internal database details"))
+	sanitized := errors.Sanitize(err)
+	suite.Require().NotNil(sanitized)
+	suite.Assert().ErrorIs(sanitized, errors.CreationFailed)
+	suite.Assert().Equal("Internal Server Error (ref: 1234)", sanitized.Error())
+
+	payload, jerr := json.Marshal(sanitized)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.creation.failed", "code": 500, "text": "Internal Server Error (ref: 1234)", "correlation_id": "1234"}`, string(payload))
+}
+
+func (suite *ErrorsSuite) TestShouldSanitizeOnlyOnce() {
+	err := errors.CreationFailed.WithCorrelationID("1234").Wrap(fmt.Errorf("internal database details"))
+	sanitized := errors.Sanitize(err)
+	suite.Assert().Equal(sanitized, errors.Sanitize(sanitized), "Sanitize should be idempotent")
+	suite.Assert().Equal("Internal Server Error (ref: 1234)", errors.Sanitize(sanitized).Error())
+
+	errors.ShowCorrelationID(true)
+	defer errors.ShowCorrelationID(false)
+	suite.Assert().Equal("Internal Server Error (ref: 1234)", errors.Sanitize(errors.Sanitize(err)).Error())
+}
+
+func (suite *ErrorsSuite) TestCanSanitizeSimpleError() {
+	sanitized := errors.Sanitize(fmt.Errorf("internal database details"))
+	suite.Require().NotNil(sanitized)
+	suite.Assert().ErrorIs(sanitized, errors.RuntimeError)
+	suite.Assert().Equal("Internal Server Error", sanitized.Error())
+	suite.Assert().Nil(errors.Sanitize(nil))
+}
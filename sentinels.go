@@ -9,9 +9,80 @@ import (
 //
 // A sentinel is an Error that hasn't been decorated with a stack trace
 //
-// Typically, it can be used to create error that can be matched later
-func NewSentinel(code int, id, message string) Error {
-	return Error{Code: code, ID: id, Text: message}
+// Typically, it can be used to create error that can be matched later.
+//
+// The sentinel is also registered so it can be found with Lookup.
+//
+// In strict mode (see StrictIDs), NewSentinel panics if the ID is not valid.
+//
+// When sentinels are frozen (see FreezeSentinels), decorating a sentinel that was modified after its creation panics.
+//
+// Options can be given to further configure the sentinel, like:
+//
+//	var UserNotFound = errors.NewSentinel(http.StatusNotFound, "error.user.notfound", "User %s Not Found", errors.DocURL("https://acme.com/errors/user-not-found"))
+func NewSentinel(code int, id, message string, options ...SentinelOption) Error {
+	if strictIDs.Load() {
+		if pattern, ok := matchID(id); !ok {
+			panic(Errorf("Invalid sentinel ID %q, IDs must match %s", id, pattern))
+		}
+	}
+	sentinel := Error{Code: code, ID: id, Text: message, template: parseText(message)}
+	for _, option := range options {
+		option(&sentinel)
+	}
+	sentinel.seal = &sentinelSeal{sentinel: *sentinel.CloneDeep()}
+	register(sentinel)
+	return sentinel
+}
+
+// SentinelOption configures a sentinel created by NewSentinel
+type SentinelOption func(sentinel *Error)
+
+// DocURL sets the URL of the documentation of a sentinel
+func DocURL(url string) SentinelOption {
+	return func(sentinel *Error) {
+		sentinel.DocURL = url
+	}
+}
+
+// Retryable marks a sentinel as retryable, i.e. the operation that failed can be attempted again
+func Retryable() SentinelOption {
+	return func(sentinel *Error) {
+		sentinel.Retryable = true
+	}
+}
+
+// MatchCode makes a sentinel match any Error with the same Code in errors.Is, not only the ones with the same ID
+//
+// Example:
+//
+//	var AnyNotFound = errors.NewSentinel(http.StatusNotFound, "error.any.notfound", "Not Found", errors.MatchCode())
+//	errors.Is(errors.HTTPNotFound.WithStack(), AnyNotFound) // true
+func MatchCode() SentinelOption {
+	return func(sentinel *Error) {
+		sentinel.matchCode = true
+	}
+}
+
+// Extends makes a sentinel match its parent in errors.Is, as well as the sentinels its parent extends
+//
+// This allows to introduce a more specific sentinel without breaking the code that checks for the generic one.
+//
+// Example:
+//
+//	var UserNotFound = errors.NewSentinel(http.StatusNotFound, "error.notfound.user", "User %s Not Found", errors.Extends(errors.NotFound))
+//	errors.Is(UserNotFound.With("john"), errors.NotFound) // true
+func Extends(parent Error) SentinelOption {
+	return func(sentinel *Error) {
+		sentinel.parents = append(append([]string{parent.ID}, parent.parents...), sentinel.parents...)
+	}
+}
+
+// SeverityLevel sets the Severity of a sentinel
+func SeverityLevel(severity Severity) SentinelOption {
+	return func(sentinel *Error) {
+		sentinel.Severity = severity
+	}
 }
 
 // FromHTTPStatusCode creates a new error of the sentinel that matches the given HTTP status code.
@@ -103,7 +174,7 @@ func FromHTTPStatusCode(code int) error {
 	case http.StatusVariantAlsoNegotiates:
 		return HTTPStatusVariantAlsoNegotiates.WithStack()
 	default:
-		return NewSentinel(code, fmt.Sprintf("error.http.%d", code), fmt.Sprintf("HTTP Status %d", code)).WithStack()
+		return Error{Code: code, ID: fmt.Sprintf("error.http.%d", code), Text: fmt.Sprintf("HTTP Status %d", code)}.WithStack()
 	}
 }
 
@@ -115,15 +186,48 @@ var ArgumentMissing = NewSentinel(http.StatusBadRequest, "error.argument.missing
 // ArgumentExpected is used when an argument is expected and another was set.
 var ArgumentExpected = NewSentinel(http.StatusBadRequest, "error.argument.expected", "Argument %s is invalid (value: %v, expected: %v)")
 
+// ArgumentOutOfRange is used when an argument is not within its allowed range, see With and WithRange.
+var ArgumentOutOfRange = NewSentinel(http.StatusBadRequest, "error.argument.outofrange", "Argument %s is out of range (value: %v)")
+
 // ArgumentInvalid is used when an argument has an unexpected value.
 var ArgumentInvalid = NewSentinel(http.StatusBadRequest, "error.argument.invalid", "Argument %s is invalid (value: %v)")
 
+// BrokenPipe is used when writing to a connection or a pipe that was closed by the other end.
+var BrokenPipe = NewSentinel(http.StatusBadGateway, "error.connection.broken", "Broken pipe", Retryable())
+
+// CauseCycle is used when the chain of causes of an error loops back on itself.
+var CauseCycle = NewSentinel(http.StatusInternalServerError, "error.cause.cycle", "Circular cause detected")
+
+// CausesTruncated is used in place of the causes that are nested too deep to be rendered, see SetMaxCauseDepth.
+var CausesTruncated = NewSentinel(http.StatusInternalServerError, "error.cause.truncated", "... and %s more causes")
+
+// ClosedPipe is used when reading from or writing to a closed pipe.
+var ClosedPipe = NewSentinel(http.StatusInternalServerError, "error.io.pipe.closed", "Read/write on closed pipe")
+
+// CommandFailed is used when an external command failed, see FromExec.
+var CommandFailed = NewSentinel(http.StatusInternalServerError, "error.command.failed", "Command %s failed (exit status: %v)")
+
+// Conflict is used when something conflicts with the current state of a resource, like a duplicate key.
+var Conflict = NewSentinel(http.StatusConflict, "error.conflict", "Conflict on %s")
+
+// ConnectionRefused is used when a server refused a connection.
+var ConnectionRefused = NewSentinel(http.StatusServiceUnavailable, "error.connection.refused", "Connection refused", Retryable())
+
+// ConnectionReset is used when a connection was reset by the other end.
+var ConnectionReset = NewSentinel(http.StatusBadGateway, "error.connection.reset", "Connection reset by peer", Retryable())
+
 // CreationFailed is used when something was not created properly.
 var CreationFailed = NewSentinel(http.StatusInternalServerError, "error.creation.failed", "Failed Creating %s")
 
+// DNSNotFound is used when a host name could not be resolved.
+var DNSNotFound = NewSentinel(http.StatusBadGateway, "error.dns.notfound", "Host %s Not Found")
+
 // Empty is used when something is empty whereas it should not.
 var Empty = NewSentinel(http.StatusBadRequest, "error.empty", "%s is empty")
 
+// EndOfStream is used when no more input is available, see FromIO.
+var EndOfStream = NewSentinel(http.StatusBadRequest, "error.io.eof", "End of stream")
+
 // EnvironmentMissing is used when an argument is missing.
 var EnvironmentMissing = NewSentinel(http.StatusBadRequest, "error.environment.missing", "Environment variable %s is missing")
 
@@ -154,6 +258,9 @@ var JSONPropertyMissing = NewSentinel(http.StatusBadRequest, "error.json.propert
 // Missing is used when something is missing.
 var Missing = NewSentinel(http.StatusBadRequest, "error.missing", "%s is missing")
 
+// MultipleErrors is used when several errors are collected, see MultiError.AsError
+var MultipleErrors = NewSentinel(http.StatusInternalServerError, "error.multiple", "Multiple errors")
+
 // NotConnected is used when some socket, client is not connected to its server.
 var NotConnected = NewSentinel(http.StatusGone, "error.client.not_connected", "%s Not Connected")
 
@@ -172,12 +279,24 @@ var IndexOutOfBounds = NewSentinel(http.StatusBadRequest, "error.index.outofboun
 // RuntimeError is used when the code failed executing something.
 var RuntimeError = NewSentinel(http.StatusInternalServerError, "error.runtime", "Runtime Error")
 
+// ShortWrite is used when a write accepted fewer bytes than requested.
+var ShortWrite = NewSentinel(http.StatusInternalServerError, "error.io.write.short", "Short write")
+
 // Timeout is used when something timed out.
 var Timeout = NewSentinel(http.StatusRequestTimeout, "error.timeout", "%s Timeout")
 
+// TLSHandshakeFailed is used when a TLS connection could not be established, like when a certificate is not trusted.
+var TLSHandshakeFailed = NewSentinel(http.StatusBadGateway, "error.tls.handshake", "TLS handshake failed")
+
 // TooManyErrors is used when something is found too many times.
 var TooManyErrors = NewSentinel(http.StatusInternalServerError, "error.toomany", "Too Many")
 
+// UnexpectedEndOfStream is used when the input ended in the middle of a block of data, like a truncated payload.
+var UnexpectedEndOfStream = NewSentinel(http.StatusBadRequest, "error.io.eof.unexpected", "Unexpected end of stream")
+
+// ValidationFailed is used when some fields do not satisfy their validation rules, see ForField and ValidationErrors.
+var ValidationFailed = NewSentinel(http.StatusUnprocessableEntity, "error.validation.failed", "Validation failed for %s")
+
 // Unauthorized is used when some credentials failed some authentication process.
 var Unauthorized = NewSentinel(http.StatusUnauthorized, "error.unauthorized", "Invalid Credentials")
 
@@ -311,3 +430,129 @@ var HTTPStatusUseProxy = NewSentinel(http.StatusUseProxy, "error.http.proxy.requ
 
 // HTTPStatusVariantAlsoNegotiates reports HTTP Error StatusVariantAlsoNegotiates.
 var HTTPStatusVariantAlsoNegotiates = NewSentinel(http.StatusVariantAlsoNegotiates, "error.http.variant.alsonegotiate", http.StatusText(http.StatusVariantAlsoNegotiates))
+
+/*********** Authentication Errors *****************************************************************************************************/
+
+// MFARequired is used when an operation requires a multi-factor authentication.
+var MFARequired = NewSentinel(http.StatusUnauthorized, "error.mfa.required", "Multi-factor authentication required")
+
+// PermissionDenied is used when the permissions of something do not allow an operation.
+var PermissionDenied = NewSentinel(http.StatusForbidden, "error.permission.denied", "Permission denied on %s")
+
+// SessionExpired is used when a session has expired and the user must sign in again.
+var SessionExpired = NewSentinel(http.StatusUnauthorized, "error.session.expired", "Session expired")
+
+// TokenExpired is used when an access token has expired, see FromJWT.
+var TokenExpired = NewSentinel(http.StatusUnauthorized, "error.token.expired", "Token expired")
+
+// TokenInvalid is used when an access token is malformed, not yet valid, or its signature cannot be verified, see FromJWT.
+var TokenInvalid = NewSentinel(http.StatusUnauthorized, "error.token.invalid", "Invalid token")
+
+// TokenMissing is used when a request does not carry an access token.
+var TokenMissing = NewSentinel(http.StatusUnauthorized, "error.token.missing", "Token missing")
+
+/*********** Configuration Errors ******************************************************************************************************/
+
+// ConfigInvalid is used when a configuration value is invalid, see FromConfig.
+var ConfigInvalid = NewSentinel(http.StatusInternalServerError, "error.config.invalid", "Configuration %s is invalid (value: %v)")
+
+// ConfigMissing is used when a configuration value is missing, see FromConfig.
+var ConfigMissing = NewSentinel(http.StatusInternalServerError, "error.config.missing", "Configuration %s is missing")
+
+// ConfigParseFailed is used when a configuration cannot be parsed, see FromConfig.
+var ConfigParseFailed = NewSentinel(http.StatusInternalServerError, "error.config.parse", "Failed parsing configuration %s")
+
+// FeatureDisabled is used when a feature is disabled by the configuration.
+var FeatureDisabled = NewSentinel(http.StatusForbidden, "error.feature.disabled", "Feature %s is disabled")
+
+/*********** Database Errors ***********************************************************************************************************/
+
+// DatabaseUnavailable is used when the connection to a database is not usable.
+var DatabaseUnavailable = NewSentinel(http.StatusServiceUnavailable, "error.database.unavailable", "Database unavailable", Retryable())
+
+// DuplicateKey is used when a row cannot be inserted or updated because its key already exists.
+var DuplicateKey = NewSentinel(http.StatusConflict, "error.database.duplicate_key", "Duplicate key %s")
+
+// MigrationFailed is used when a database schema migration failed.
+var MigrationFailed = NewSentinel(http.StatusInternalServerError, "error.database.migration.failed", "Migration %s failed")
+
+// QueryFailed is used when a database query failed.
+var QueryFailed = NewSentinel(http.StatusInternalServerError, "error.database.query.failed", "Query %s failed")
+
+// SerializationFailure is used when a transaction was aborted because of concurrent transactions, it can be attempted again.
+var SerializationFailure = NewSentinel(http.StatusConflict, "error.database.serialization", "Could not serialize access due to concurrent updates", Retryable())
+
+// TransactionDone is used when a database transaction was already committed or rolled back.
+var TransactionDone = NewSentinel(http.StatusInternalServerError, "error.database.transaction.done", "Transaction has already been committed or rolled back")
+
+// TransactionFailed is used when a database transaction could not be committed.
+var TransactionFailed = NewSentinel(http.StatusInternalServerError, "error.database.transaction.failed", "Transaction failed")
+
+/*********** Rate Limit Errors *********************************************************************************************************/
+
+// ConcurrencyLimitExceeded is used when too many requests are processed at the same time, see WithLimit.
+var ConcurrencyLimitExceeded = NewSentinel(http.StatusTooManyRequests, "error.concurrency.limit.exceeded", "Too many concurrent requests for %s", Retryable())
+
+// QuotaExceeded is used when a quota, like a number of calls per month, is exhausted, see WithLimit.
+var QuotaExceeded = NewSentinel(http.StatusTooManyRequests, "error.quota.exceeded", "Quota exceeded for %s")
+
+// RateLimitExceeded is used when too many requests were sent in a given amount of time, see WithLimit.
+var RateLimitExceeded = NewSentinel(http.StatusTooManyRequests, "error.ratelimit.exceeded", "Rate limit exceeded for %s", Retryable())
+
+/*********** SMTP Errors ***************************************************************************************************************/
+
+// SMTPServiceUnavailable is used when a mail server is not available (SMTP 421).
+var SMTPServiceUnavailable = NewSentinel(http.StatusServiceUnavailable, "error.smtp.service.unavailable", "Mail service unavailable: %s")
+
+// SMTPMailboxUnavailable is used when a mailbox does not exist or cannot be used (SMTP 450, 550).
+var SMTPMailboxUnavailable = NewSentinel(http.StatusBadGateway, "error.smtp.mailbox.unavailable", "Mailbox unavailable: %s")
+
+// SMTPLocalError is used when a mail server failed processing a message (SMTP 451).
+var SMTPLocalError = NewSentinel(http.StatusBadGateway, "error.smtp.local", "Mail server error: %s")
+
+// SMTPInsufficientStorage is used when a mail server or a mailbox has not enough storage (SMTP 452, 552).
+var SMTPInsufficientStorage = NewSentinel(http.StatusInsufficientStorage, "error.smtp.storage.insufficient", "Insufficient mail storage: %s")
+
+// SMTPSyntaxError is used when a mail server did not understand a command or its arguments (SMTP 500, 501).
+var SMTPSyntaxError = NewSentinel(http.StatusBadGateway, "error.smtp.syntax", "Mail command syntax error: %s")
+
+// SMTPCommandNotImplemented is used when a mail server does not implement a command or one of its parameters (SMTP 502, 504).
+var SMTPCommandNotImplemented = NewSentinel(http.StatusBadGateway, "error.smtp.command.notimplemented", "Mail command not implemented: %s")
+
+// SMTPBadSequence is used when mail commands were sent in the wrong order (SMTP 503).
+var SMTPBadSequence = NewSentinel(http.StatusBadGateway, "error.smtp.sequence", "Bad sequence of mail commands: %s")
+
+// SMTPAuthenticationFailed is used when a mail server rejected the credentials or requires authentication (SMTP 530, 535).
+var SMTPAuthenticationFailed = NewSentinel(http.StatusUnauthorized, "error.smtp.authentication", "Mail authentication failed: %s")
+
+// SMTPUserNotLocal is used when a mail server does not accept mail for a recipient (SMTP 551).
+var SMTPUserNotLocal = NewSentinel(http.StatusBadGateway, "error.smtp.user.notlocal", "Mail recipient not local: %s")
+
+// SMTPMailboxNameInvalid is used when a mailbox name is not allowed (SMTP 553).
+var SMTPMailboxNameInvalid = NewSentinel(http.StatusBadRequest, "error.smtp.mailbox.invalid", "Mailbox name invalid: %s")
+
+// SMTPTransactionFailed is used when a mail server rejected a message, like spam (SMTP 554).
+var SMTPTransactionFailed = NewSentinel(http.StatusBadGateway, "error.smtp.transaction", "Mail transaction failed: %s")
+
+// SMTPTransientFailure is used for the other transient mail failures (SMTP 4xx), the operation can be attempted again.
+var SMTPTransientFailure = NewSentinel(http.StatusBadGateway, "error.smtp.transient", "Transient mail failure: %s", Retryable())
+
+// SMTPPermanentFailure is used for the other permanent mail failures (SMTP 5xx).
+var SMTPPermanentFailure = NewSentinel(http.StatusBadGateway, "error.smtp.permanent", "Permanent mail failure: %s")
+
+/*********** Storage Errors ************************************************************************************************************/
+
+// ChecksumMismatch is used when the checksum of a file or an object does not match the expected one.
+var ChecksumMismatch = NewSentinel(http.StatusBadRequest, "error.checksum.mismatch", "Checksum mismatch for %s (value: %v)")
+
+// DiskFull is used when there is no space left on a device.
+var DiskFull = NewSentinel(http.StatusInsufficientStorage, "error.disk.full", "No space left on device")
+
+// FileAccessDenied is used when a file cannot be accessed, it is in the "error.permission.denied" category and matches PermissionDenied.
+var FileAccessDenied = NewSentinel(http.StatusForbidden, "error.permission.denied.file", "Access denied on file %s", Extends(PermissionDenied))
+
+// FileNotFound is used when a file does not exist, it is in the "error.notfound" category and matches NotFound.
+var FileNotFound = NewSentinel(http.StatusNotFound, "error.notfound.file", "File %s Not Found", Extends(NotFound))
+
+// PathInvalid is used when a path is not valid, like when it is too long or one of its components is not a directory.
+var PathInvalid = NewSentinel(http.StatusBadRequest, "error.path.invalid", "Invalid path %s")
@@ -1,7 +1,10 @@
 package errors_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gildas/go-errors"
 )
@@ -144,3 +147,64 @@ func (suite *ErrorsSuite) TestCanCreateFromHTTPStatus() {
 	suite.Require().True(errors.As(err, &details), "error should be a error.Error")
 	suite.Assert().Equal(1234, details.Code, "Error code should be 1234")
 }
+
+func (suite *ErrorsSuite) TestCanCreateSentinelWithDocURL() {
+	requireStack(suite.T())
+	sentinel := errors.NewSentinel(http.StatusNotFound, "error.test.user.notfound", "User %s Not Found", errors.DocURL("https://acme.com/errors/user-not-found"))
+	suite.Assert().Equal("https://acme.com/errors/user-not-found", sentinel.DocURL)
+
+	err := sentinel.With("john")
+	suite.Assert().Equal("https://acme.com/errors/user-not-found", err.(errors.Error).DocURL, "With should keep the DocURL")
+	err = sentinel.Wrap(fmt.Errorf("simple error"))
+	suite.Assert().Equal("https://acme.com/errors/user-not-found", err.(errors.Error).DocURL, "Wrap should keep the DocURL")
+	suite.Assert().True(strings.HasPrefix(fmt.Sprintf("%+v", sentinel.With("john")), "User john Not Found\nsee: https://acme.com/errors/user-not-found\n"))
+
+	payload, jerr := json.Marshal(sentinel)
+	suite.Require().Nil(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.test.user.notfound", "code": 404, "text": "User %s Not Found", "doc_url": "https://acme.com/errors/user-not-found"}`, string(payload))
+
+	suite.Assert().Equal("https://acme.com/other", errors.NotFound.WithDocURL("https://acme.com/other").DocURL)
+	suite.Assert().Empty(errors.NotFound.DocURL, "NotFound should not have changed")
+}
+
+func (suite *ErrorsSuite) TestCanUseDatabaseSentinels() {
+	sentinels := []struct {
+		sentinel  errors.Error
+		code      int
+		retryable bool
+	}{
+		{errors.DatabaseUnavailable, http.StatusServiceUnavailable, true},
+		{errors.DuplicateKey, http.StatusConflict, false},
+		{errors.MigrationFailed, http.StatusInternalServerError, false},
+		{errors.QueryFailed, http.StatusInternalServerError, false},
+		{errors.SerializationFailure, http.StatusConflict, true},
+		{errors.TransactionDone, http.StatusInternalServerError, false},
+		{errors.TransactionFailed, http.StatusInternalServerError, false},
+	}
+	for _, item := range sentinels {
+		suite.Assert().NoError(errors.ValidateID(item.sentinel.ID))
+		suite.Assert().True(item.sentinel.InCategory("error.database"), "%s should be a database error", item.sentinel.ID)
+		suite.Assert().Equal(item.code, item.sentinel.Code, "Code of %s", item.sentinel.ID)
+		suite.Assert().Equal(item.retryable, item.sentinel.Retryable, "Retryable of %s", item.sentinel.ID)
+		_, found := errors.Lookup(item.sentinel.ID)
+		suite.Assert().True(found, "%s should be registered", item.sentinel.ID)
+	}
+	err := errors.DuplicateKey.With("users_email_key")
+	suite.Assert().Equal("Duplicate key users_email_key", err.Error())
+}
+
+func (suite *ErrorsSuite) TestCanExtendSentinel() {
+	userNotFound := errors.NewSentinel(http.StatusNotFound, "error.test.notfound.user", "User %s Not Found", errors.Extends(errors.FileNotFound))
+	err := userNotFound.With("john")
+	suite.Assert().ErrorIs(err, userNotFound)
+	suite.Assert().ErrorIs(err, errors.FileNotFound)
+	suite.Assert().ErrorIs(err, errors.NotFound, "the parents of the parent should match too")
+	suite.Assert().NotErrorIs(errors.NotFound.With("user", "john"), userNotFound, "the parent should not match its children")
+	suite.Assert().NotErrorIs(err, errors.PermissionDenied)
+
+	payload, jerr := json.Marshal(err)
+	suite.Require().NoError(jerr)
+	var unmarshaled errors.Error
+	suite.Require().NoError(json.Unmarshal(payload, &unmarshaled))
+	suite.Assert().ErrorIs(unmarshaled, errors.NotFound)
+}
@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// ServiceInfo describes the service and the host where an Error was created
+type ServiceInfo struct {
+	Name     string `json:"name,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	PID      int    `json:"pid,omitempty"`
+}
+
+var serviceInfo atomic.Pointer[ServiceInfo]
+
+// SetServiceInfo sets the service information to stamp on every created Error.
+//
+// The hostname and the process ID are captured as well.
+//
+// If name is empty, errors are not stamped anymore.
+func SetServiceInfo(name, version string) {
+	if len(name) == 0 {
+		serviceInfo.Store(nil)
+		return
+	}
+	hostname, _ := os.Hostname()
+	serviceInfo.Store(&ServiceInfo{
+		Name:     name,
+		Version:  version,
+		Hostname: hostname,
+		PID:      os.Getpid(),
+	})
+}
+
+// GetServiceInfo returns the current service information, if any
+func GetServiceInfo() *ServiceInfo {
+	return serviceInfo.Load()
+}
+
+// enrich stamps the current service information on this Error
+//
+// If enabled, the build information is stamped as well.
+//
+// Then, the package-wide Hooks are called with this Error, see Config.
+func (e *Error) enrich() {
+	if e.Service == nil {
+		e.Service = serviceInfo.Load()
+	}
+	if e.Build == nil && buildInfoEnabled.Load() {
+		e.Build = GetBuildInfo()
+	}
+	e.runHooks()
+}
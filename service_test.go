@@ -0,0 +1,48 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanStampServiceInfo() {
+	suite.Assert().Nil(errors.GetServiceInfo(), "service info should not be set by default")
+	err := errors.NotFound.With("user", "john")
+	suite.Assert().Nil(err.(errors.Error).Service, "err should not have service info")
+
+	errors.SetServiceInfo("billing", "1.2.3")
+	defer errors.SetServiceInfo("", "")
+
+	hostname, _ := os.Hostname()
+	err = errors.NotFound.With("user", "john")
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Require().NotNil(details.Service, "err should have service info")
+	suite.Assert().Equal("billing", details.Service.Name)
+	suite.Assert().Equal("1.2.3", details.Service.Version)
+	suite.Assert().Equal(hostname, details.Service.Hostname)
+	suite.Assert().Equal(os.Getpid(), details.Service.PID)
+	suite.Assert().Nil(errors.NotFound.Service, "NotFound should not have changed")
+
+	err = errors.WithStack(fmt.Errorf("simple error"))
+	suite.Assert().NotNil(err.(errors.Error).Service, "err should have service info")
+}
+
+func (suite *ErrorsSuite) TestCanMarshalServiceInfo() {
+	errors.SetServiceInfo("billing", "1.2.3")
+	defer errors.SetServiceInfo("", "")
+
+	hostname, _ := os.Hostname()
+	expected := fmt.Sprintf(`{"type": "error", "version": 1, "id": "error.notimplemented", "code": 501, "text": "Not Implemented", "origin": {"name": "billing", "version": "1.2.3", "hostname": %q, "pid": %d}}`, hostname, os.Getpid())
+	payload, err := json.Marshal(errors.NotImplemented.WithStack())
+	suite.Require().Nil(err)
+	suite.Assert().JSONEq(expected, string(payload))
+
+	var unmarshaled errors.Error
+	suite.Require().Nil(json.Unmarshal(payload, &unmarshaled))
+	suite.Require().NotNil(unmarshaled.Service)
+	suite.Assert().Equal("billing", unmarshaled.Service.Name)
+}
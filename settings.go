@@ -0,0 +1,176 @@
+package errors
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Config gathers the settings of this package
+//
+// The package-wide Config is changed with SetConfig or with the individual setters, like SetMaxCauseDepth.
+//
+// Libraries and applications that need different settings without changing the package-wide ones
+// can use their own Config in a scope, see WithConfig and Config.Apply.
+//
+// A Config should be obtained with CurrentConfig and modified, as the zero value of some settings means "no limit".
+type Config struct {
+	// MaxCauseDepth is the maximum number of nested causes to render, 0 means no limit, see SetMaxCauseDepth
+	MaxCauseDepth int
+	// MaxMessageLength is the maximum number of characters of the rendered messages, 0 means no limit, see SetMaxMessageLength
+	MaxMessageLength int
+	// DisableStackCapture tells if the errors should not carry a StackTrace, see DisableStackCapture
+	DisableStackCapture bool
+	// CauseSeparator is written between an Error and its cause, empty means DefaultCauseSeparator, see SetCauseSeparator
+	CauseSeparator string
+	// CompactCauseSeparator is written between an Error and its cause by %-v, empty means DefaultCompactCauseSeparator
+	CompactCauseSeparator string
+	// Redactors are applied in order by Sanitize
+	Redactors []Redactor
+	// Hooks are called with every Error that is created, or, for a scoped Config, with every Error given to Apply
+	Hooks []Hook
+	// Locale is the locale of the messages, like "fr-FR", for the applications that translate them, see ConfigOf
+	Locale string
+}
+
+// Redactor returns a copy of the given Error without the information that must not be disclosed
+type Redactor func(err Error) Error
+
+// Hook is called with errors, like to count or to log them
+//
+// Hooks must not modify the Error they receive.
+type Hook func(err Error)
+
+// globalConfig contains the package-wide settings that do not have their own setter
+var globalConfig atomic.Pointer[Config]
+
+// CurrentConfig returns the package-wide Config
+func CurrentConfig() Config {
+	config := Config{}
+	if current := globalConfig.Load(); current != nil {
+		config = *current
+	}
+	config.MaxCauseDepth = int(maxCauseDepth.Load())
+	config.MaxMessageLength = int(maxMessageLength.Load())
+	config.DisableStackCapture = stackCaptureDisabled.Load()
+	config.CauseSeparator = causeSeparator.Load().(string)
+	config.CompactCauseSeparator = compactCauseSeparator.Load().(string)
+	return config
+}
+
+// SetConfig sets the package-wide Config
+//
+// Example:
+//
+//	config := errors.CurrentConfig()
+//	config.MaxCauseDepth = 10
+//	config.Hooks = append(config.Hooks, func(err errors.Error) { errorCounter.WithLabelValues(err.ID).Inc() })
+//	errors.SetConfig(config)
+func SetConfig(config Config) {
+	SetMaxCauseDepth(config.MaxCauseDepth)
+	SetMaxMessageLength(config.MaxMessageLength)
+	stackCaptureDisabled.Store(config.DisableStackCapture)
+	SetCauseSeparator(valueOr(config.CauseSeparator, DefaultCauseSeparator))
+	SetCompactCauseSeparator(valueOr(config.CompactCauseSeparator, DefaultCompactCauseSeparator))
+	globalConfig.Store(&Config{Redactors: config.Redactors, Hooks: config.Hooks, Locale: config.Locale})
+}
+
+type configContextKey struct{}
+
+// WithConfig returns a copy of the given context that carries the given Config
+//
+// The errors given to WithContext with that context get that Config, see Config.Apply.
+//
+// Example:
+//
+//	ctx = errors.WithConfig(ctx, config)
+//	...
+//	return errors.WithContext(ctx, err) // err is rendered with config
+func WithConfig(ctx context.Context, config Config) context.Context {
+	return context.WithValue(ctx, configContextKey{}, &config)
+}
+
+// ConfigFromContext returns the Config carried by the given context, or the package-wide Config
+func ConfigFromContext(ctx context.Context) Config {
+	if config, ok := ctx.Value(configContextKey{}).(*Config); ok && config != nil {
+		return *config
+	}
+	return CurrentConfig()
+}
+
+// ConfigOf returns the Config the given error was scoped with, or the package-wide Config
+//
+// Example:
+//
+//	message := translate(errors.ConfigOf(err).Locale, err)
+func ConfigOf(err error) Config {
+	if details, ok := asError(err); ok && details.config != nil {
+		return *details.config
+	}
+	return CurrentConfig()
+}
+
+// Apply scopes the given error with this Config
+//
+// The error is rendered with the settings of this Config instead of the package-wide ones,
+// it loses its StackTrace if the stack capture is disabled, and the Hooks of this Config are called with it.
+//
+// If err is not an Error, it is wrapped in a RuntimeError with a stack trace first.
+//
+// If err is nil, Apply returns nil.
+func (config Config) Apply(err error) error {
+	if err == nil {
+		return nil
+	}
+	final, ok := asError(err)
+	if !ok {
+		final = RuntimeError.derive()
+		final.Origin = err
+		final.initializeStack()
+	} else {
+		final = final.derive()
+	}
+	final.config = &config
+	if config.DisableStackCapture {
+		final.Stack = StackTrace{}
+	}
+	for _, hook := range config.Hooks {
+		hook(final)
+	}
+	return final
+}
+
+// causeSeparators returns the separators between this Error and its cause
+func (e Error) causeSeparators() (separator, compact string) {
+	if e.config != nil {
+		return valueOr(e.config.CauseSeparator, DefaultCauseSeparator), valueOr(e.config.CompactCauseSeparator, DefaultCompactCauseSeparator)
+	}
+	return causeSeparator.Load().(string), compactCauseSeparator.Load().(string)
+}
+
+// redactors returns the Redactors to apply to this Error
+func (e Error) redactors() []Redactor {
+	if e.config != nil {
+		return e.config.Redactors
+	}
+	if config := globalConfig.Load(); config != nil {
+		return config.Redactors
+	}
+	return nil
+}
+
+// runHooks calls the package-wide Hooks with this Error
+func (e Error) runHooks() {
+	if config := globalConfig.Load(); config != nil {
+		for _, hook := range config.Hooks {
+			hook(e)
+		}
+	}
+}
+
+// valueOr returns value, or fallback if value is empty
+func valueOr(value, fallback string) string {
+	if len(value) == 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,73 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanGetAndSetConfig() {
+	original := errors.CurrentConfig()
+	defer errors.SetConfig(original)
+	suite.Assert().Equal(errors.DefaultMaxCauseDepth, original.MaxCauseDepth)
+	suite.Assert().Equal(errors.DefaultCauseSeparator, original.CauseSeparator)
+
+	var hooked []string
+	config := errors.CurrentConfig()
+	config.CauseSeparator = " | "
+	config.Locale = "fr-FR"
+	config.Hooks = []errors.Hook{func(err errors.Error) { hooked = append(hooked, err.ID) }}
+	errors.SetConfig(config)
+
+	err := errors.CreationFailed.With("user").(errors.Error).WithCause(errors.ArgumentMissing.With("name"))
+	suite.Assert().Equal("Failed Creating user | Argument name is missing", err.Error())
+	suite.Assert().Equal([]string{"error.creation.failed", "error.argument.missing"}, hooked)
+	suite.Assert().Equal("fr-FR", errors.ConfigOf(err).Locale)
+	suite.Assert().Equal(" | ", errors.CurrentConfig().CauseSeparator)
+}
+
+func (suite *ErrorsSuite) TestCanScopeConfig() {
+	var hooked int
+	scoped := errors.CurrentConfig()
+	scoped.CauseSeparator = " <- "
+	scoped.MaxCauseDepth = 1
+	scoped.DisableStackCapture = true
+	scoped.Locale = "ja-JP"
+	scoped.Hooks = []errors.Hook{func(err errors.Error) { hooked++ }}
+	scoped.Redactors = []errors.Redactor{func(err errors.Error) errors.Error {
+		err.Value = "***"
+		return err
+	}}
+	ctx := errors.WithConfig(context.Background(), scoped)
+	suite.Assert().Equal("ja-JP", errors.ConfigFromContext(ctx).Locale)
+	suite.Assert().Empty(errors.ConfigFromContext(context.Background()).Locale)
+
+	original := errors.CreationFailed.With("user").(errors.Error).WithCause(errors.JSONMarshalError.Wrap(errors.NotFound.With("user", "john")))
+	err := errors.WithContext(ctx, original)
+	suite.Assert().Equal("Failed Creating user <- JSON failed to marshal data <- ... and 1 more causes", err.Error())
+	suite.Assert().Equal(1, hooked)
+	suite.Assert().Empty(err.(errors.Error).Stack)
+	suite.Assert().Equal("ja-JP", errors.ConfigOf(err).Locale)
+	suite.Assert().Equal("Failed Creating user\nCaused by:\n\tJSON failed to marshal data\nCaused by:\n\tuser john Not Found", original.Error(), "the package-wide config should not change")
+
+	sanitized := errors.Sanitize(scoped.Apply(errors.ArgumentInvalid.With("password", "s3cr3t")))
+	suite.Assert().Equal("***", sanitized.(errors.Error).Value)
+	suite.Assert().Equal("s3cr3t", errors.Sanitize(errors.ArgumentInvalid.With("password", "s3cr3t")).(errors.Error).Value)
+
+	simple := scoped.Apply(fmt.Errorf("simple error"))
+	suite.Assert().Equal("simple error", simple.Error())
+	suite.Assert().ErrorIs(simple, errors.RuntimeError)
+	suite.Assert().Equal(500, simple.(errors.Error).Code)
+	suite.Assert().Equal("ja-JP", errors.ConfigOf(simple).Locale)
+	suite.Assert().Nil(scoped.Apply(nil))
+}
+
+func (suite *ErrorsSuite) TestCanScopeConfigWithFrozenSentinels() {
+	errors.FreezeSentinels(true)
+	defer errors.FreezeSentinels(false)
+
+	err := errors.Config{Locale: "ja-JP"}.Apply(errors.NotFound)
+	suite.Assert().NotPanics(func() { _ = err.(errors.Error).WithField("key", "value") })
+	suite.Assert().Equal("ja-JP", errors.ConfigOf(err).Locale)
+}
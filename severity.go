@@ -0,0 +1,27 @@
+package errors
+
+// Severity tells how severe an Error is
+type Severity string
+
+const (
+	// SeverityInfo is used for errors that are only informational
+	SeverityInfo Severity = "info"
+	// SeverityWarning is used for errors that do not prevent the operation from completing
+	SeverityWarning Severity = "warning"
+	// SeverityError is used for errors that prevent the operation from completing, this is the default
+	SeverityError Severity = "error"
+	// SeverityCritical is used for errors that prevent the whole application from working
+	SeverityCritical Severity = "critical"
+)
+
+// String returns the string version of this Severity
+//
+// An empty Severity is SeverityError.
+//
+// implements fmt.Stringer
+func (severity Severity) String() string {
+	if len(severity) == 0 {
+		return string(SeverityError)
+	}
+	return string(severity)
+}
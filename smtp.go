@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// smtpSentinels maps the SMTP reply codes to their sentinels
+var smtpSentinels = map[int]Error{
+	421: SMTPServiceUnavailable,
+	450: SMTPMailboxUnavailable,
+	451: SMTPLocalError,
+	452: SMTPInsufficientStorage,
+	500: SMTPSyntaxError,
+	501: SMTPSyntaxError,
+	502: SMTPCommandNotImplemented,
+	503: SMTPBadSequence,
+	504: SMTPCommandNotImplemented,
+	530: SMTPAuthenticationFailed,
+	535: SMTPAuthenticationFailed,
+	550: SMTPMailboxUnavailable,
+	551: SMTPUserNotLocal,
+	552: SMTPInsufficientStorage,
+	553: SMTPMailboxNameInvalid,
+	554: SMTPTransactionFailed,
+}
+
+// enhancedStatusCode matches the enhanced mail status codes of RFC 3463, like: "5.1.1"
+var enhancedStatusCode = regexp.MustCompile(`^[245]\.\d{1,3}\.\d{1,3}\b`)
+
+// FromSMTP converts an SMTP reply code and message into an Error
+//
+// The known reply codes are mapped to their SMTP sentinels, the others to SMTPTransientFailure (4xx)
+// or SMTPPermanentFailure (5xx). Transient failures (4xx) are retryable, permanent failures (5xx) are not,
+// so bounces can be handled by checking the Retryable field.
+//
+// The message goes to the What of the Error, the reply code to the "smtp_code" attribute,
+// and the enhanced status code (RFC 3463) at the beginning of the message, if any, to the "smtp_status" attribute.
+//
+// The reply codes of *textproto.Error, as returned by net/smtp, can be given directly.
+//
+// If the reply code is not an error (below 400), FromSMTP returns nil.
+//
+// FromSMTP also records the stack trace at the point it was called.
+//
+// Example:
+//
+//	var protoErr *textproto.Error
+//	if errors.As(err, &protoErr) {
+//	  return errors.FromSMTP(protoErr.Code, protoErr.Msg)
+//	}
+func FromSMTP(code int, message string) error {
+	if code < 400 {
+		return nil
+	}
+	final, found := smtpSentinels[code]
+	if !found {
+		if code < 500 {
+			final = SMTPTransientFailure
+		} else {
+			final = SMTPPermanentFailure
+		}
+	}
+	final = final.derive()
+	final.Retryable = code < 500
+	message = strings.TrimSpace(message)
+	final.What = message
+	if len(message) == 0 {
+		final.What = strconv.Itoa(code)
+	}
+	final.Attributes = map[string]interface{}{"smtp_code": code}
+	if status := enhancedStatusCode.FindString(message); len(status) > 0 {
+		final.Attributes["smtp_status"] = status
+	}
+	final.initializeStack()
+	final.enrich()
+	return final
+}
@@ -0,0 +1,44 @@
+package errors_test
+
+import (
+	"net/textproto"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanConvertSMTPReplies() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromSMTP(250, "OK"))
+
+	err := errors.FromSMTP(550, "5.1.1 The email account that you tried to reach does not exist")
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.SMTPMailboxUnavailable)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().False(details.Retryable)
+	suite.Assert().Equal(550, details.Attributes["smtp_code"])
+	suite.Assert().Equal("5.1.1", details.Attributes["smtp_status"])
+	suite.Assert().Equal("Mailbox unavailable: 5.1.1 The email account that you tried to reach does not exist", details.Error())
+	suite.Assert().NotEmpty(details.Stack)
+
+	err = errors.FromSMTP(450, "4.2.1 Mailbox busy")
+	suite.Assert().ErrorIs(err, errors.SMTPMailboxUnavailable)
+	suite.Assert().True(err.(errors.Error).Retryable)
+}
+
+func (suite *ErrorsSuite) TestCanConvertUnknownSMTPReplies() {
+	err := errors.FromSMTP(471, "")
+	suite.Assert().ErrorIs(err, errors.SMTPTransientFailure)
+	suite.Assert().True(err.(errors.Error).Retryable)
+	suite.Assert().Equal("Transient mail failure: 471", err.Error())
+	suite.Assert().Nil(err.(errors.Error).Attributes["smtp_status"])
+
+	err = errors.FromSMTP(557, "rejected")
+	suite.Assert().ErrorIs(err, errors.SMTPPermanentFailure)
+	suite.Assert().False(err.(errors.Error).Retryable)
+
+	protoErr := &textproto.Error{Code: 535, Msg: "5.7.8 Username and Password not accepted"}
+	err = errors.FromSMTP(protoErr.Code, protoErr.Msg)
+	suite.Assert().ErrorIs(err, errors.SMTPAuthenticationFailed)
+	suite.Assert().True(errors.IsCode(err, 401))
+}
@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// SOAPNamespace is the namespace of SOAP 1.1 envelopes
+const SOAPNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+
+// soapFault is the SOAP 1.1 Fault element
+type soapFault struct {
+	XMLName     xml.Name   `xml:"soap:Fault"`
+	Namespace   string     `xml:"xmlns:soap,attr"`
+	FaultCode   string     `xml:"faultcode"`
+	FaultString string     `xml:"faultstring"`
+	Detail      soapDetail `xml:"detail"`
+}
+
+// soapDetail is the detail element of a SOAP Fault
+type soapDetail struct {
+	Error soapError `xml:"error"`
+}
+
+// soapError is the XML form of an Error
+type soapError struct {
+	Code          int             `xml:"code,omitempty"`
+	ID            string          `xml:"id,omitempty"`
+	Message       string          `xml:"message,omitempty"`
+	What          string          `xml:"what,omitempty"`
+	Value         string          `xml:"value,omitempty"`
+	Hint          string          `xml:"hint,omitempty"`
+	DocURL        string          `xml:"doc_url,omitempty"`
+	CorrelationID string          `xml:"correlation_id,omitempty"`
+	Attributes    *soapAttributes `xml:"attributes,omitempty"`
+	Causes        []soapError     `xml:"cause,omitempty"`
+}
+
+// soapAttributes is the XML form of the Attributes of an Error
+type soapAttributes struct {
+	Attributes []soapAttribute `xml:"attribute"`
+}
+
+// soapAttribute is the XML form of an attribute of an Error
+type soapAttribute struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// MarshalSOAPFault marshals err into a SOAP 1.1 Fault element, for services that must speak to SOAP consumers
+//
+// The faultcode is "soap:Client" for errors with a 4xx Code, "soap:Server" otherwise,
+// the faultstring is the message of the first Error of err's chain (without its causes),
+// and the detail element contains the structured error and its causes, like:
+//
+//	<soap:Fault xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+//	  <faultcode>soap:Client</faultcode>
+//	  <faultstring>Argument email is missing</faultstring>
+//	  <detail>
+//	    <error><code>400</code><id>error.argument.missing</id><message>Argument email is missing</message><what>email</what></error>
+//	  </detail>
+//	</soap:Fault>
+//
+// If err is nil, MarshalSOAPFault returns nil.
+func MarshalSOAPFault(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	return toJSONCause(err).MarshalSOAPFault()
+}
+
+// MarshalSOAPFault marshals this Error into a SOAP 1.1 Fault element, see MarshalSOAPFault
+func (e Error) MarshalSOAPFault() ([]byte, error) {
+	fault := soapFault{
+		Namespace:   SOAPNamespace,
+		FaultCode:   "soap:Server",
+		FaultString: e.message(),
+		Detail:      soapDetail{Error: e.bounded().toSOAP()},
+	}
+	if e.Code >= http.StatusBadRequest && e.Code < http.StatusInternalServerError {
+		fault.FaultCode = "soap:Client"
+	}
+	payload, err := xml.MarshalIndent(fault, "", "  ")
+	if err != nil {
+		return nil, WithStack(err)
+	}
+	return payload, nil
+}
+
+// toSOAP converts this Error and its causes into their XML form
+//
+// This Error must not have cycles, see bounded.
+func (e Error) toSOAP() soapError {
+	final := soapError{
+		Code:          e.Code,
+		ID:            e.ID,
+		Message:       e.message(),
+		What:          e.What,
+		Hint:          e.Hint,
+		DocURL:        e.DocURL,
+		CorrelationID: e.CorrelationID,
+	}
+	if e.Value != nil {
+		final.Value = fmt.Sprint(e.Value)
+	}
+	if len(e.Attributes) > 0 {
+		keys := make([]string, 0, len(e.Attributes))
+		for key := range e.Attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		final.Attributes = &soapAttributes{}
+		for _, key := range keys {
+			final.Attributes.Attributes = append(final.Attributes.Attributes, soapAttribute{Key: key, Value: fmt.Sprint(e.Attributes[key])})
+		}
+	}
+	for _, cause := range e.Causes() {
+		final.Causes = append(final.Causes, toJSONCause(cause).toSOAP())
+	}
+	return final
+}
@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanMarshalSOAPFault() {
+	err := errors.ArgumentMissing.With("email").(errors.Error).WithField("form", "signup").WithCause(fmt.Errorf("form is empty"))
+	payload, merr := errors.MarshalSOAPFault(err)
+	suite.Require().NoError(merr)
+	expected := `<soap:Fault xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <faultcode>soap:Client</faultcode>
+  <faultstring>Argument email is missing</faultstring>
+  <detail>
+    <error>
+      <code>400</code>
+      <id>error.argument.missing</id>
+      <message>Argument email is missing</message>
+      <what>email</what>
+      <attributes>
+        <attribute key="form">signup</attribute>
+      </attributes>
+      <cause>
+        <code>500</code>
+        <id>error.runtime</id>
+        <message>form is empty</message>
+      </cause>
+    </error>
+  </detail>
+</soap:Fault>`
+	suite.Assert().Equal(expected, string(payload))
+
+	var fault struct {
+		FaultCode   string `xml:"faultcode"`
+		FaultString string `xml:"faultstring"`
+	}
+	suite.Require().NoError(xml.Unmarshal(payload, &fault))
+	suite.Assert().Equal("soap:Client", fault.FaultCode)
+}
+
+func (suite *ErrorsSuite) TestCanMarshalSOAPFaultOfServerErrors() {
+	payload, err := errors.MarshalSOAPFault(fmt.Errorf("boom"))
+	suite.Require().NoError(err)
+	suite.Assert().Contains(string(payload), "<faultcode>soap:Server</faultcode>")
+	suite.Assert().Contains(string(payload), "<faultstring>boom</faultstring>")
+
+	payload, err = errors.MarshalSOAPFault(nil)
+	suite.Assert().NoError(err)
+	suite.Assert().Nil(payload)
+}
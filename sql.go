@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"slices"
+	"sync"
+)
+
+// SQLMapper maps a driver-specific error to an error of this package
+//
+// The mapper returns false if it does not know the error.
+//
+// Example, for github.com/lib/pq:
+//
+//	errors.RegisterSQLMapper("pq", func(err error) (error, bool) {
+//	  var pqErr *pq.Error
+//	  if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+//	    return errors.Conflict.Wrapf(err, "%s", pqErr.Constraint), true
+//	  }
+//	  return nil, false
+//	})
+type SQLMapper func(err error) (error, bool)
+
+type namedSQLMapper struct {
+	name   string
+	mapper SQLMapper
+}
+
+var (
+	sqlMappers     []namedSQLMapper
+	sqlMappersLock sync.RWMutex
+)
+
+// RegisterSQLMapper registers a mapper that FromSQL will use for driver-specific errors
+//
+// The mappers are tried in the order they were registered.
+// If a mapper was already registered with that name, it is replaced and keeps its place.
+func RegisterSQLMapper(name string, mapper SQLMapper) {
+	sqlMappersLock.Lock()
+	defer sqlMappersLock.Unlock()
+	for index, item := range sqlMappers {
+		if item.name == name {
+			sqlMappers[index].mapper = mapper
+			return
+		}
+	}
+	sqlMappers = append(sqlMappers, namedSQLMapper{name: name, mapper: mapper})
+}
+
+// UnregisterSQLMapper removes the mapper registered with the given name
+func UnregisterSQLMapper(name string) {
+	sqlMappersLock.Lock()
+	defer sqlMappersLock.Unlock()
+	for index, item := range sqlMappers {
+		if item.name == name {
+			sqlMappers = append(sqlMappers[:index], sqlMappers[index+1:]...)
+			return
+		}
+	}
+}
+
+// FromSQL converts an error from the database/sql package or from a database driver into an Error
+//
+// The registered SQLMapper funcs are tried first, in the order they were registered, then the errors are mapped to the following sentinels:
+//
+//	sql.ErrNoRows                        -> NotFound
+//	sql.ErrTxDone                        -> TransactionDone
+//	sql.ErrConnDone, driver.ErrBadConn   -> DatabaseUnavailable (retryable)
+//
+// If err is nil, FromSQL returns nil. Errors and *Error are returned as is.
+func FromSQL(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := asError(err); ok {
+		return err
+	}
+	if mapped, ok := mapSQL(err); ok {
+		return mapped
+	}
+	var final Error
+	switch {
+	case Is(err, sql.ErrNoRows):
+		final = NotFound.derive()
+		final.What = "database"
+		final.Value = "row"
+	case Is(err, sql.ErrTxDone):
+		final = TransactionDone.derive()
+	case Is(err, sql.ErrConnDone), Is(err, driver.ErrBadConn):
+		final = DatabaseUnavailable.derive()
+	default:
+		return WithStack(err)
+	}
+	final.Cause = err
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// registeredSQLMappers returns a copy of the registered SQLMapper funcs, so they can be called without holding the lock
+func registeredSQLMappers() []namedSQLMapper {
+	sqlMappersLock.RLock()
+	defer sqlMappersLock.RUnlock()
+	return slices.Clone(sqlMappers)
+}
+
+// mapSQL runs the registered SQLMapper funcs on the given error, in the order they were registered
+func mapSQL(err error) (error, bool) {
+	for _, item := range registeredSQLMappers() {
+		if mapped, ok := item.mapper(err); ok {
+			return mapped, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,99 @@
+package errors_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+type duplicateKeyError struct {
+	Constraint string
+}
+
+func (err duplicateKeyError) Error() string {
+	return "duplicate key value violates unique constraint " + err.Constraint
+}
+
+func (suite *ErrorsSuite) TestCanConvertSQLErrors() {
+	requireStack(suite.T())
+	suite.Assert().Nil(errors.FromSQL(nil))
+	pointer := errors.NotFound.Clone()
+	suite.Assert().Equal(pointer, errors.FromSQL(pointer), "a *Error should be returned as is")
+
+	err := errors.FromSQL(sql.ErrNoRows)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().ErrorIs(err, sql.ErrNoRows)
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+
+	err = errors.FromSQL(fmt.Errorf("commit: %w", sql.ErrTxDone))
+	suite.Assert().ErrorIs(err, errors.TransactionDone)
+
+	err = errors.FromSQL(driver.ErrBadConn)
+	suite.Assert().ErrorIs(err, errors.DatabaseUnavailable)
+	suite.Assert().True(err.(errors.Error).Retryable)
+
+	err = errors.FromSQL(sql.ErrConnDone)
+	suite.Assert().ErrorIs(err, errors.DatabaseUnavailable)
+
+	err = errors.FromSQL(fmt.Errorf("syntax error"))
+	suite.Assert().Equal("error.runtime", err.(errors.Error).ID)
+}
+
+func (suite *ErrorsSuite) TestCanConvertSQLErrorsWithMapper() {
+	errors.RegisterSQLMapper("test", func(err error) (error, bool) {
+		var duplicate duplicateKeyError
+		if errors.As(err, &duplicate) {
+			return errors.Conflict.Wrapf(err, "%s", duplicate.Constraint), true
+		}
+		return nil, false
+	})
+	defer errors.UnregisterSQLMapper("test")
+
+	err := errors.FromSQL(duplicateKeyError{Constraint: "users_email_key"})
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.Conflict)
+	suite.Assert().Equal(409, err.(errors.Error).Code)
+	suite.Assert().Equal("users_email_key", err.(errors.Error).What)
+
+	err = errors.FromSQL(sql.ErrNoRows)
+	suite.Assert().ErrorIs(err, errors.NotFound, "unknown errors should fall back to the standard mapping")
+
+	errors.UnregisterSQLMapper("test")
+	err = errors.FromSQL(duplicateKeyError{Constraint: "users_email_key"})
+	suite.Assert().NotErrorIs(err, errors.Conflict)
+}
+
+func (suite *ErrorsSuite) TestShouldTrySQLMappersInRegistrationOrder() {
+	errors.RegisterSQLMapper("first", func(err error) (error, bool) {
+		return errors.Conflict.Wrap(err), true
+	})
+	defer errors.UnregisterSQLMapper("first")
+	errors.RegisterSQLMapper("second", func(err error) (error, bool) {
+		return errors.DatabaseUnavailable.Wrap(err), true
+	})
+	defer errors.UnregisterSQLMapper("second")
+
+	for attempt := 0; attempt < 10; attempt++ {
+		suite.Assert().ErrorIs(errors.FromSQL(duplicateKeyError{Constraint: "users_email_key"}), errors.Conflict)
+	}
+
+	errors.RegisterSQLMapper("first", func(err error) (error, bool) {
+		return nil, false
+	})
+	suite.Assert().ErrorIs(errors.FromSQL(duplicateKeyError{Constraint: "users_email_key"}), errors.DatabaseUnavailable, "the replaced mapper should keep its place")
+}
+
+func (suite *ErrorsSuite) TestCanRegisterSQLMapperFromMapper() {
+	errors.RegisterSQLMapper("registering", func(err error) (error, bool) {
+		errors.RegisterSQLMapper("registered", func(err error) (error, bool) {
+			return nil, false
+		})
+		return errors.Conflict.Wrap(err), true
+	})
+	defer errors.UnregisterSQLMapper("registering")
+	defer errors.UnregisterSQLMapper("registered")
+	suite.Assert().ErrorIs(errors.FromSQL(duplicateKeyError{Constraint: "users_email_key"}), errors.Conflict)
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"path"
+	"strings"
+)
+
+// Stable returns err formatted like %+v, but without anything that depends on the machine or the build
+//
+// The source files are shown without their path and line number, and the frames in assembly files, like runtime.goexit, are dropped.
+// Thus the output can be compared with golden files in tests.
+//
+// Example:
+//
+//	fmt.Println(errors.Stable(errors.NotImplemented.WithStack()))
+//	// Not Implemented
+//	// main.main
+//	//	main.go
+//	// runtime.main
+//	//	proc.go
+//
+// If err is nil, Stable returns an empty string.
+func Stable(err error) string {
+	if err == nil {
+		return ""
+	}
+	var sb strings.Builder
+
+	_, _ = sb.WriteString(err.Error())
+	value, ok := asError(err)
+	if !ok {
+		return sb.String()
+	}
+	if len(value.Hint) > 0 {
+		_, _ = sb.WriteString("\nHint: ")
+		_, _ = sb.WriteString(value.Hint)
+	}
+	if len(value.DocURL) > 0 {
+		_, _ = sb.WriteString("\nsee: ")
+		_, _ = sb.WriteString(value.DocURL)
+	}
+	for _, frame := range value.Stack.visible() {
+		if strings.HasSuffix(frame.Filepath(), ".s") {
+			continue
+		}
+		_, _ = sb.WriteString("\n")
+		_, _ = sb.WriteString(frame.FuncName())
+		_, _ = sb.WriteString("\n\t")
+		_, _ = sb.WriteString(path.Base(frame.Filepath()))
+	}
+	return sb.String()
+}
+
+// Stable returns this Error formatted like %+v, but without anything that depends on the machine or the build.
+//
+// See Stable.
+func (e Error) Stable() string {
+	return Stable(e)
+}
@@ -0,0 +1,23 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanFormatStable() {
+	requireStack(suite.T())
+	err := errors.CreationFailed.With("user").(errors.Error).WithHint("check the database").WithCause(errors.NotFound.With("user", "john"))
+	text := errors.Stable(err)
+	suite.Assert().Contains(text, "Failed Creating user\nCaused by:\n\tuser john Not Found\nHint: check the database\ngithub.com/gildas/go-errors_test.(*ErrorsSuite).TestCanFormatStable\n\tstable_test.go\n")
+	suite.Assert().NotContains(text, ".go:")
+	suite.Assert().NotContains(text, "goexit")
+	suite.Assert().Equal(text, err.Stable())
+	suite.Assert().Equal(text, errors.Stable(err), "Stable should be stable")
+}
+
+func (suite *ErrorsSuite) TestCanFormatStableWithOtherErrors() {
+	suite.Assert().Equal("boom", errors.Stable(fmt.Errorf("boom")))
+	suite.Assert().Empty(errors.Stable(nil))
+}
@@ -0,0 +1,89 @@
+//go:build !errors_nostack
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+)
+
+// Initialize initializes the StackTrace with the callers of the current func
+//
+// If the stack capture is disabled, the StackTrace is empty, see DisableStackCapture.
+func (st *StackTrace) Initialize() {
+	st.capture(4) // skip extern.go, capture, this func, Error.func
+}
+
+// capture initializes the StackTrace with the callers, skipping the given number of frames
+func (st *StackTrace) capture(skip int) {
+	if stackCaptureDisabled.Load() {
+		*st = StackTrace{}
+		return
+	}
+	const depth = 32
+	var counters [depth]uintptr
+	count := runtime.Callers(skip, counters[:])
+	*st = make(StackTrace, 0, count)
+	for i := 0; i < count; i++ {
+		if frame := StackFrame(counters[i]); !frame.isHelper() {
+			*st = append(*st, frame)
+		}
+	}
+}
+
+// Format formats the stack of Frames according to the fmt.Formatter interface.
+//
+//	%s	lists source files for each Frame in the stack
+//	%v	lists the source file and line number for each Frame in the stack
+//
+// Format accepts flags that alter the printing of some verbs, as follows:
+//
+//	%+v   Prints filename, function, and line number for each Frame in the stack.
+//	      The source lines of the top application frames are printed as well, see ShowSourceLines.
+//
+// A precision limits the output to the first frames, like: %+.3v prints the top 3 frames, see Top.
+//
+// The frames dropped by SetFrameFilter or MarkHelper are not formatted.
+func (st StackTrace) Format(s fmt.State, verb rune) {
+	st = st.visible()
+	if precision, ok := s.Precision(); ok {
+		st = st.Top(precision)
+	}
+	switch verb {
+	case 'v':
+		switch {
+		case s.Flag('+'):
+			sources := int(sourceLines.Load())
+			for _, f := range st {
+				fmt.Fprintf(s, "\n%+v", f)
+				if sources > 0 && !f.IsStandard() {
+					if source, found := f.Source(); found {
+						_, _ = io.WriteString(s, "\n\t> ")
+						_, _ = io.WriteString(s, source)
+					}
+					sources--
+				}
+			}
+		case s.Flag('#'):
+			fmt.Fprintf(s, "%#v", []StackFrame(st))
+		default:
+			st.formatSlice(s, verb)
+		}
+	case 's':
+		st.formatSlice(s, verb)
+	}
+}
+
+// formatSlice will format this StackTrace into the given buffer as a slice of
+// Frame, only valid when called with '%s' or '%v'.
+func (st StackTrace) formatSlice(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, "[")
+	for i, f := range st {
+		if i > 0 {
+			_, _ = io.WriteString(s, " ")
+		}
+		f.Format(s, verb)
+	}
+	_, _ = io.WriteString(s, "]")
+}
@@ -0,0 +1,10 @@
+//go:build !errors_nostack
+
+package errors_test
+
+import "testing"
+
+// requireStack does nothing as this package captures stack traces
+func requireStack(t *testing.T) {
+	t.Helper()
+}
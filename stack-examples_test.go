@@ -0,0 +1,99 @@
+//go:build !errors_nostack
+
+package errors_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func ExampleError_Format_withStack() {
+	output := CaptureStdout(func() {
+		err := errors.NotImplemented.WithStack()
+		if err != nil {
+			fmt.Printf("%+v", err)
+		}
+	})
+	// remove the path of each file and line numbers as they change for each Go deployments
+	lines := strings.Split(output, "\n")
+	simplifier := regexp.MustCompile(`\s*(.*/)?(.*):[0-9]+`)
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		lines[i] = simplifier.ReplaceAllString(line, "  ${2}")
+	}
+	// we also do not care about last line that is machine dependent
+	fmt.Println(strings.Join(lines[0:len(lines)-1], "\n"))
+	// Output:
+	// Not Implemented
+	// github.com/gildas/go-errors_test.ExampleError_Format_withStack.func1
+	//   stack-examples_test.go
+	// github.com/gildas/go-errors_test.CaptureStdout
+	//   errors_test.go
+	// github.com/gildas/go-errors_test.ExampleError_Format_withStack
+	//   stack-examples_test.go
+	// testing.runExample
+	//   run_example.go
+	// testing.runExamples
+	//   example.go
+	// testing.(*M).Run
+	//   testing.go
+	// main.main
+	//   _testmain.go
+	// runtime.main
+	//   proc.go
+	// runtime.goexit
+}
+
+func ExampleError_Format_gosyntax_01() {
+	output := CaptureStdout(func() {
+		err := errors.WrapErrors(errors.ArgumentInvalid.With("key", "value"), errors.ArgumentMissing.With("key"))
+		if err != nil {
+			fmt.Printf("%#v\n", err)
+		}
+	})
+	// remove the line numbers from the stack trace as they change when the code is changed
+	simplifier := regexp.MustCompile(`\.go:[0-9]+`)
+	// we also do not care about the last file which is machine dependent
+	noasm := regexp.MustCompile(`, asm_.[^\.]+.s:[0-9]+`)
+	fmt.Println(noasm.ReplaceAllString(simplifier.ReplaceAllString(output, ".go"), ""))
+	// Output:
+	// errors.Error{Code: 400, ID: "error.argument.invalid", Text: "Argument %s is invalid (value: %v)", What: "key", Value: "value", Cause: errors.Error{Code: 400, ID: "error.argument.missing", Text: "Argument %s is missing", What: "key", Stack: []errors.StackFrame{stack-examples_test.go, errors_test.go, stack-examples_test.go, run_example.go, example.go, testing.go, _testmain.go, proc.go}}, Stack: []errors.StackFrame{stack-examples_test.go, errors_test.go, stack-examples_test.go, run_example.go, example.go, testing.go, _testmain.go, proc.go}}
+}
+
+func ExampleError_Format_gosyntax_02() {
+	output := CaptureStdout(func() {
+		err := errors.WrapErrors(errors.ArgumentInvalid.With("key", "value"), fmt.Errorf("unknown error"))
+		if err != nil {
+			fmt.Printf("%#v\n", err)
+		}
+	})
+	// remove the line numbers from the stack trace as they change when the code is changed
+	simplifier := regexp.MustCompile(`\.go:[0-9]+`)
+	// we also do not care about the last file which is machine dependent
+	noasm := regexp.MustCompile(`, asm_.[^\.]+.s:[0-9]+`)
+	fmt.Println(noasm.ReplaceAllString(simplifier.ReplaceAllString(output, ".go"), ""))
+	// Output:
+	// errors.Error{Code: 400, ID: "error.argument.invalid", Text: "Argument %s is invalid (value: %v)", What: "key", Value: "value", Cause: "unknown error", Stack: []errors.StackFrame{stack-examples_test.go, errors_test.go, stack-examples_test.go, run_example.go, example.go, testing.go, _testmain.go, proc.go}}
+}
+
+func ExampleStable() {
+	err := errors.NotImplemented.WithStack()
+	fmt.Println(errors.Stable(err))
+	// Output:
+	// Not Implemented
+	// github.com/gildas/go-errors_test.ExampleStable
+	//	stack-examples_test.go
+	// testing.runExample
+	//	run_example.go
+	// testing.runExamples
+	//	example.go
+	// testing.(*M).Run
+	//	testing.go
+	// main.main
+	//	_testmain.go
+	// runtime.main
+	//	proc.go
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"path"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 /*
@@ -47,6 +49,65 @@ func (frame StackFrame) FuncName() string {
 	return function.Name()
 }
 
+// Package returns the import path of the package of this frame's function, like: "github.com/gildas/go-errors"
+func (frame StackFrame) Package() string {
+	name := frame.FuncName()
+	if name == "unknown" {
+		return name
+	}
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
+
+// ShortFuncName returns the name of this frame's function without the path of its package, like: "go-errors.(*Error).Wrap"
+func (frame StackFrame) ShortFuncName() string {
+	name := frame.FuncName()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+var sourceFiles sync.Map
+
+// Source returns the source line of this frame, without its indentation
+//
+// Source returns false if the source file is not available.
+func (frame StackFrame) Source() (string, bool) {
+	line := frame.Line()
+	if line <= 0 {
+		return "", false
+	}
+	var lines []string
+	if cached, found := sourceFiles.Load(frame.Filepath()); found {
+		lines = cached.([]string)
+	} else {
+		content, err := os.ReadFile(frame.Filepath())
+		if err == nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		sourceFiles.Store(frame.Filepath(), lines)
+	}
+	if line > len(lines) {
+		return "", false
+	}
+	return strings.TrimSpace(lines[line-1]), true
+}
+
+// IsStandard tells if this frame belongs to the Go standard library, like "runtime" or "net/http"
+func (frame StackFrame) IsStandard() bool {
+	name := frame.FuncName()
+	if name == "unknown" {
+		return false
+	}
+	// Like the go command, standard packages are the ones whose path does not start with a domain name
+	first, _, found := strings.Cut(name, "/")
+	if !found {
+		first, _, _ = strings.Cut(name, ".")
+	}
+	return first != "main" && !strings.Contains(first, ".")
+}
+
 func (frame StackFrame) MarshalText() ([]byte, error) {
 	funcName := frame.FuncName()
 	if funcName == "unknown" {
@@ -0,0 +1,21 @@
+//go:build errors_nostack
+
+package errors
+
+import "fmt"
+
+// Initialize does nothing as this package is built with the errors_nostack tag
+//
+// The StackTrace is always empty, like when the stack capture is disabled, see DisableStackCapture.
+func (st *StackTrace) Initialize() {
+	*st = StackTrace{}
+}
+
+// capture does nothing as this package is built with the errors_nostack tag
+func (st *StackTrace) capture(skip int) {
+	*st = StackTrace{}
+}
+
+// Format does nothing as this package is built with the errors_nostack tag
+func (st StackTrace) Format(s fmt.State, verb rune) {
+}
@@ -0,0 +1,11 @@
+//go:build errors_nostack
+
+package errors_test
+
+import "testing"
+
+// requireStack skips the current test as this package is built with the errors_nostack tag
+func requireStack(t *testing.T) {
+	t.Helper()
+	t.Skip("stack traces are not captured with the errors_nostack tag")
+}
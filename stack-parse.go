@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParsedFrame is a frame of a stack trace read from text, see ParseStackTrace
+//
+// Unlike StackFrame, a ParsedFrame does not need the program that produced it.
+type ParsedFrame struct {
+	// Func is the full name of the function, like: "github.com/acme/app.(*Server).Start"
+	Func string `json:"func"`
+	// File is the path of the source file, if known
+	File string `json:"path,omitempty"`
+	// Line is the line in the source file, if known
+	Line int `json:"line,omitempty"`
+}
+
+// ParsedStackTrace is a stack trace read from text, see ParseStackTrace
+type ParsedStackTrace []ParsedFrame
+
+// sourceLocation matches the "file:line" part of a frame, with the optional program counter offset of Go tracebacks
+var sourceLocation = regexp.MustCompile(`^(.+):(\d+)(?:\s+\+0x[0-9a-fA-F]+)?$`)
+
+// callArguments matches the arguments that Go tracebacks print after the function name, like: "(0xc000010000, 0x1)"
+var callArguments = regexp.MustCompile(`\([^()]*\)$`)
+
+// ParseStackTrace reads a stack trace from text, like the ones copied from logs
+//
+// ParseStackTrace understands:
+//
+//   - the text of StackTrace.MarshalText, one "func file:line" per line,
+//   - the text of StackTrace.String and %+v, "func" and "\tfile:line" on 2 lines,
+//   - the tracebacks of Go panics, with their function arguments and program counter offsets.
+//
+// The lines it does not understand, like goroutine headers, source lines, or log prefixes that precede the trace, are ignored.
+//
+// If text contains no frame at all, ParseStackTrace returns an ArgumentInvalid error.
+func ParseStackTrace(text string) (ParsedStackTrace, error) {
+	var trace ParsedStackTrace
+	var pending *ParsedFrame
+	flush := func() {
+		if pending != nil {
+			trace = append(trace, *pending)
+			pending = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, ">") || strings.HasPrefix(line, "...") || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		name, location, _ := strings.Cut(callArguments.ReplaceAllString(line, ""), " ")
+		if name == "created" && strings.HasPrefix(location, "by ") {
+			name, _, _ = strings.Cut(strings.TrimPrefix(location, "by "), " ")
+			location = ""
+		}
+		if !isFuncName(name) {
+			if file, number, found := parseSourceLocation(line); found && pending != nil {
+				pending.File, pending.Line = file, number
+				flush()
+			}
+			continue
+		}
+		flush()
+		frame := ParsedFrame{Func: name}
+		if file, number, found := parseSourceLocation(strings.TrimSpace(location)); found {
+			frame.File, frame.Line = file, number
+			trace = append(trace, frame)
+		} else if len(location) == 0 {
+			pending = &frame
+		}
+	}
+	flush()
+	if len(trace) == 0 && len(strings.TrimSpace(text)) > 0 {
+		return nil, ArgumentInvalid.With("stack trace", firstLine(text))
+	}
+	return trace, nil
+}
+
+// parseSourceLocation parses a "file:line" source location
+func parseSourceLocation(text string) (file string, line int, found bool) {
+	matches := sourceLocation.FindStringSubmatch(text)
+	if matches == nil {
+		return "", 0, false
+	}
+	line, err := strconv.Atoi(matches[2])
+	return matches[1], line, err == nil
+}
+
+// isFuncName tells if the given text can be the name of a function, as opposed to a path or a log prefix
+func isFuncName(text string) bool {
+	if text == "unknown" {
+		return true
+	}
+	return strings.Contains(text, ".") && !strings.HasPrefix(text, "/") && !strings.ContainsAny(text, `:\`)
+}
+
+// firstLine returns the first line of the given text
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return line
+}
+
+// String returns the string version of this ParsedFrame, like: "github.com/acme/app.main /src/app/main.go:12"
+//
+// implements fmt.Stringer
+func (frame ParsedFrame) String() string {
+	if len(frame.File) == 0 {
+		return frame.Func
+	}
+	return fmt.Sprintf("%s %s:%d", frame.Func, frame.File, frame.Line)
+}
+
+// MarshalText marshals this into text, like StackFrame.MarshalText
+//
+// implements encoding.TextMarshaler
+func (frame ParsedFrame) MarshalText() ([]byte, error) {
+	return []byte(frame.String()), nil
+}
+
+// String returns the string version of this ParsedStackTrace, one frame per line
+//
+// implements fmt.Stringer
+func (trace ParsedStackTrace) String() string {
+	lines := make([]string, 0, len(trace))
+	for _, frame := range trace {
+		lines = append(lines, frame.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MarshalText marshals this into text, like StackTrace.MarshalText
+//
+// implements encoding.TextMarshaler
+func (trace ParsedStackTrace) MarshalText() ([]byte, error) {
+	return []byte(trace.String()), nil
+}
+
+// UnmarshalText unmarshals the given text into this, see ParseStackTrace
+//
+// StackTrace cannot be unmarshaled as its frames are program counters that only make sense in the program that captured them.
+//
+// implements encoding.TextUnmarshaler
+func (trace *ParsedStackTrace) UnmarshalText(text []byte) error {
+	parsed, err := ParseStackTrace(string(text))
+	if err != nil {
+		return err
+	}
+	*trace = parsed
+	return nil
+}
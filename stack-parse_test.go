@@ -0,0 +1,76 @@
+package errors_test
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanMarshalStackTraceAsText() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	payload, merr := err.Stack.MarshalText()
+	suite.Require().NoError(merr)
+	suite.Assert().Regexp(`^github.com/gildas/go-errors_test.\(\*ErrorsSuite\).TestCanMarshalStackTraceAsText /.*/stack-parse_test.go:11\n`, string(payload))
+
+	trace, perr := errors.ParseStackTrace(string(payload))
+	suite.Require().NoError(perr)
+	suite.Require().Len(trace, len(err.Stack))
+	for index, frame := range err.Stack {
+		suite.Assert().Equal(frame.FuncName(), trace[index].Func)
+		suite.Assert().Equal(frame.Filepath(), trace[index].File)
+		suite.Assert().Equal(frame.Line(), trace[index].Line)
+	}
+	suite.Assert().Equal(string(payload), trace.String())
+}
+
+func (suite *ErrorsSuite) TestCanParseFormattedStackTrace() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	trace, perr := errors.ParseStackTrace(err.Stack.String())
+	suite.Require().NoError(perr)
+	suite.Require().Len(trace, len(err.Stack))
+	suite.Assert().Equal(err.Stack[0].FuncName(), trace[0].Func)
+	suite.Assert().Equal(err.Stack[0].Line(), trace[0].Line)
+}
+
+func (suite *ErrorsSuite) TestCanParseGoTraceback() {
+	text := `2024/05/01 12:00:00 something went wrong
+panic: boom [recovered]
+
+goroutine 1 [running]:
+github.com/acme/app.(*Server).Start(0xc000010000, {0x1, 0x2})
+	/src/app/server.go:42 +0x1d
+github.com/acme/app.Run[...](...)
+	/src/app/run.go:12
+main.main()
+	/src/app/main.go:7 +0x25
+...additional frames elided...
+created by main.main in goroutine 1
+	/src/app/main.go:5 +0x3c
+`
+	trace, err := errors.ParseStackTrace(text)
+	suite.Require().NoError(err)
+	suite.Require().Len(trace, 4, trace.String())
+	suite.Assert().Equal(errors.ParsedFrame{Func: "github.com/acme/app.(*Server).Start", File: "/src/app/server.go", Line: 42}, trace[0])
+	suite.Assert().Equal(errors.ParsedFrame{Func: "github.com/acme/app.Run[...]", File: "/src/app/run.go", Line: 12}, trace[1])
+	suite.Assert().Equal(errors.ParsedFrame{Func: "main.main", File: "/src/app/main.go", Line: 7}, trace[2])
+	suite.Assert().Equal(errors.ParsedFrame{Func: "main.main", File: "/src/app/main.go", Line: 5}, trace[3])
+}
+
+func (suite *ErrorsSuite) TestCanUnmarshalParsedStackTrace() {
+	var payload struct {
+		Stack errors.ParsedStackTrace `json:"stack"`
+	}
+	err := json.Unmarshal([]byte(`{"stack": "main.main /src/app/main.go:7\nunknown"}`), &payload)
+	suite.Require().NoError(err)
+	suite.Require().Len(payload.Stack, 2)
+	suite.Assert().Equal("main.main /src/app/main.go:7", payload.Stack[0].String())
+	suite.Assert().Equal("unknown", payload.Stack[1].String())
+
+	err = json.Unmarshal([]byte(`{"stack": "nothing to see here"}`), &payload)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+}
@@ -1,9 +1,14 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
-	"io"
+	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 /*
@@ -12,52 +17,200 @@ Imported from https://github.com/pkg/errors/blob/master/stack.go
 
 type StackTrace []StackFrame
 
-// Initialize initializes the StackTrace with the callers of the current func
-func (st *StackTrace) Initialize() {
-	const depth = 32
-	var counters [depth]uintptr
-	count := runtime.Callers(3, counters[:]) // skip extern.go, this func, Error.func
-	*st = make(StackTrace, count)
-	for i := 0; i < count; i++ {
-		(*st)[i] = StackFrame(counters[i])
+// StackTracer describes errors that carry a StackTrace, like github.com/pkg/errors.StackTracer
+type StackTracer interface {
+	StackTrace() StackTrace
+}
+
+var sourceLines atomic.Int32
+
+// ShowSourceLines tells how many application frames show their source line when formatted with %+v or rendered with ToHTML
+//
+// The source lines are read from the source files, if they are available on the machine running the program.
+// The frames of the Go standard library do not show their source line.
+//
+// By default, no source line is shown.
+func ShowSourceLines(count int) {
+	sourceLines.Store(int32(max(count, 0)))
+}
+
+// NoStackEnvironmentVariable is the environment variable that disables the stack capture when set to a true value, like "1"
+const NoStackEnvironmentVariable = "GO_ERRORS_NOSTACK"
+
+var stackCaptureDisabled atomic.Bool
+
+func init() {
+	if disabled, err := strconv.ParseBool(os.Getenv(NoStackEnvironmentVariable)); err == nil {
+		stackCaptureDisabled.Store(disabled)
+	}
+}
+
+// DisableStackCapture stops capturing stack traces when errors are created, to save time and memory
+//
+// The errors can still be created with With, WithStack, Wrap, etc, but their StackTrace is empty.
+//
+// The stack capture can also be disabled with the GO_ERRORS_NOSTACK environment variable.
+func DisableStackCapture() {
+	stackCaptureDisabled.Store(true)
+}
+
+// EnableStackCapture captures stack traces again when errors are created, which is the default
+func EnableStackCapture() {
+	stackCaptureDisabled.Store(false)
+}
+
+var (
+	helpers    sync.Map
+	hasHelpers atomic.Bool
+)
+
+// MarkHelper marks the calling function as a helper, like testing.T.Helper
+//
+// The frames of helper functions are skipped when capturing and formatting StackTraces,
+// so the traces start at the callers of the helpers.
+//
+// Example:
+//
+//	func notFound(what string) error {
+//	  errors.MarkHelper()
+//	  return errors.NotFound.With(what)
+//	}
+func MarkHelper() {
+	var counters [1]uintptr
+	if runtime.Callers(2, counters[:]) == 0 {
+		return
+	}
+	if _, loaded := helpers.LoadOrStore(StackFrame(counters[0]).FuncName(), true); !loaded {
+		hasHelpers.Store(true)
+	}
+}
+
+// isHelper tells if this frame belongs to a function marked with MarkHelper
+func (frame StackFrame) isHelper() bool {
+	if !hasHelpers.Load() {
+		return false
+	}
+	_, found := helpers.Load(frame.FuncName())
+	return found
+}
+
+// Frames returns the frames of this StackTrace as runtime.Frames, for tools that consume runtime frames
+//
+// Unlike StackFrame, runtime.Frames expands the functions that were inlined by the compiler.
+func (st StackTrace) Frames() *runtime.Frames {
+	counters := make([]uintptr, len(st))
+	for i, frame := range st {
+		counters[i] = uintptr(frame)
 	}
+	return runtime.CallersFrames(counters)
+}
+
+// String returns the function, source file, and line of each frame of this StackTrace, one frame per line
+//
+// implements fmt.Stringer
+func (st StackTrace) String() string {
+	return strings.TrimPrefix(fmt.Sprintf("%+v", st), "\n")
 }
 
-// Format formats the stack of Frames according to the fmt.Formatter interface.
+// Top returns the first n frames of this StackTrace
 //
-//	%s	lists source files for each Frame in the stack
-//	%v	lists the source file and line number for each Frame in the stack
+// If n is negative or greater than the number of frames, the whole StackTrace is returned.
+//
+// Example:
+//
+//	log.Printf("failed at %s", err.Stack.Top(1))
+func (st StackTrace) Top(n int) StackTrace {
+	if n < 0 || n >= len(st) {
+		return st
+	}
+	return st[:n]
+}
+
+// commonSuffix returns the number of frames at the end of this StackTrace that are also at the end of the other StackTrace
+func (st StackTrace) commonSuffix(other StackTrace) int {
+	count := 0
+	for count < len(st) && count < len(other) && st[len(st)-1-count] == other[len(other)-1-count] {
+		count++
+	}
+	return count
+}
+
+// Filter returns the frames of this StackTrace whose function name does not start with any of the given prefixes
+//
+// Example:
+//
+//	stack.Filter("runtime.", "testing.", "github.com/acme/middleware")
+func (st StackTrace) Filter(prefixes ...string) StackTrace {
+	if len(prefixes) == 0 {
+		return st
+	}
+	filtered := make(StackTrace, 0, len(st))
+	for _, frame := range st {
+		name := frame.FuncName()
+		skip := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			filtered = append(filtered, frame)
+		}
+	}
+	return filtered
+}
+
+var skippedFramePrefixes atomic.Pointer[[]string]
+
+// SetFrameFilter sets the prefixes of the functions whose frames are dropped when formatting and marshaling StackTraces
 //
-// Format accepts flags that alter the printing of some verbs, as follows:
+// For example, SetFrameFilter("runtime.", "testing.") hides the frames of the Go runtime and of the test runner.
 //
-//	%+v   Prints filename, function, and line number for each Frame in the stack.
-func (st StackTrace) Format(s fmt.State, verb rune) {
-	switch verb {
-	case 'v':
-		switch {
-		case s.Flag('+'):
-			for _, f := range st {
-				fmt.Fprintf(s, "\n%+v", f)
+// Calling SetFrameFilter without prefixes shows all the frames again, which is the default.
+func SetFrameFilter(prefixes ...string) {
+	prefixes = append([]string{}, prefixes...)
+	skippedFramePrefixes.Store(&prefixes)
+}
+
+// visible returns the frames of this StackTrace that are not dropped by SetFrameFilter or MarkHelper
+func (st StackTrace) visible() StackTrace {
+	if prefixes := skippedFramePrefixes.Load(); prefixes != nil {
+		st = st.Filter(*prefixes...)
+	}
+	if hasHelpers.Load() {
+		filtered := make(StackTrace, 0, len(st))
+		for _, frame := range st {
+			if !frame.isHelper() {
+				filtered = append(filtered, frame)
 			}
-		case s.Flag('#'):
-			fmt.Fprintf(s, "%#v", []StackFrame(st))
-		default:
-			st.formatSlice(s, verb)
 		}
-	case 's':
-		st.formatSlice(s, verb)
+		st = filtered
 	}
+	return st
 }
 
-// formatSlice will format this StackTrace into the given buffer as a slice of
-// Frame, only valid when called with '%s' or '%v'.
-func (st StackTrace) formatSlice(s fmt.State, verb rune) {
-	_, _ = io.WriteString(s, "[")
-	for i, f := range st {
-		if i > 0 {
-			_, _ = io.WriteString(s, " ")
+// MarshalJSON marshals this into JSON
+//
+// The frames dropped by SetFrameFilter or MarkHelper are not marshaled.
+func (st StackTrace) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal([]StackFrame(st.visible()))
+	return data, JSONMarshalError.Wrap(err)
+}
+
+// MarshalText marshals this into text, one frame per line, like: "github.com/acme/app.main /src/app/main.go:12"
+//
+// The frames dropped by SetFrameFilter or MarkHelper are not marshaled. See ParseStackTrace to read the text back.
+//
+// implements encoding.TextMarshaler
+func (st StackTrace) MarshalText() ([]byte, error) {
+	var text strings.Builder
+	for index, frame := range st.visible() {
+		if index > 0 {
+			text.WriteByte('\n')
 		}
-		f.Format(s, verb)
+		line, _ := frame.MarshalText()
+		text.Write(line)
 	}
-	_, _ = io.WriteString(s, "]")
+	return []byte(text.String()), nil
 }
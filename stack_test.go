@@ -10,15 +10,17 @@ import (
 )
 
 func (suite *ErrorsSuite) TestCanFormatStackTrace() {
+	requireStack(suite.T())
 	err := errors.NotImplemented.WithStack()
 	actual, ok := err.(errors.Error)
 	suite.Require().True(ok)
 	suite.Require().NotEmpty(actual.Stack, "The stack should not be empty")
-	suite.Assert().Contains(fmt.Sprintf("%v", actual.Stack), "[stack_test.go:13 value.go")
+	suite.Assert().Contains(fmt.Sprintf("%v", actual.Stack), "[stack_test.go:14 value.go")
 	suite.Assert().Contains(fmt.Sprintf("%s", actual.Stack), "[stack_test.go value.go")
 }
 
 func (suite *ErrorsSuite) TestCanFormatStackFrame() {
+	requireStack(suite.T())
 	err := errors.NotImplemented.WithStack()
 	actual, ok := err.(errors.Error)
 	suite.Require().True(ok)
@@ -34,6 +36,7 @@ func (suite *ErrorsSuite) TestCanMarshalStackTrace() {
 }
 
 func (suite *ErrorsSuite) TestCanMarshalStackFrameAsText() {
+	requireStack(suite.T())
 	err := errors.NotImplemented.WithStack()
 	actual, ok := err.(errors.Error)
 	suite.Require().True(ok)
@@ -55,3 +58,127 @@ func (suite *ErrorsSuite) TestCanUseInvalidStackFrame() {
 	pattern := regexp.MustCompile(`<StackFrame>unknown</StackFrame>`)
 	suite.Assert().Regexp(pattern, string(payload))
 }
+
+func (suite *ErrorsSuite) TestCanFilterStackTrace() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	filtered := err.Stack.Filter("testing.", "runtime.")
+	suite.Assert().Less(len(filtered), len(err.Stack))
+	for _, frame := range filtered {
+		suite.Assert().NotContains(frame.FuncName(), "testing.")
+		suite.Assert().NotContains(frame.FuncName(), "runtime.")
+	}
+	suite.Assert().Equal(err.Stack, err.Stack.Filter())
+	suite.Assert().Equal(filtered, err.WithoutFrames("testing.", "runtime.").Stack)
+}
+
+func (suite *ErrorsSuite) TestCanSetFrameFilter() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().Contains(fmt.Sprintf("%+v", err), "testing.tRunner")
+
+	defer errors.SetFrameFilter()
+	errors.SetFrameFilter("testing.", "runtime.")
+	suite.Assert().NotContains(fmt.Sprintf("%+v", err), "testing.tRunner")
+	suite.Assert().Contains(fmt.Sprintf("%+v", err), "TestCanSetFrameFilter")
+
+	payload, merr := json.Marshal(err.Stack)
+	suite.Require().Nil(merr)
+	suite.Assert().NotContains(string(payload), "testing.tRunner")
+	suite.Assert().Contains(string(payload), "TestCanSetFrameFilter")
+}
+
+func notImplementedHelper() error {
+	errors.MarkHelper()
+	return errors.NotImplemented.WithStack()
+}
+
+func (suite *ErrorsSuite) TestCanMarkHelper() {
+	requireStack(suite.T())
+	err := notImplementedHelper().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	suite.Assert().Equal("(*ErrorsSuite).TestCanMarkHelper", fmt.Sprintf("%n", err.Stack[0]))
+	suite.Assert().NotContains(fmt.Sprintf("%+v", err), "notImplementedHelper")
+}
+
+func (suite *ErrorsSuite) TestCanDisableStackCapture() {
+	requireStack(suite.T())
+	defer errors.EnableStackCapture()
+	errors.DisableStackCapture()
+	err := errors.NotFound.With("user", "john")
+	suite.Assert().Empty(err.(errors.Error).Stack)
+	suite.Assert().Equal("user john Not Found", fmt.Sprintf("%+v", err))
+	suite.Assert().Empty(errors.WithStack(errors.ArgumentMissing).(errors.Error).Stack)
+
+	errors.EnableStackCapture()
+	suite.Assert().NotEmpty(errors.NotFound.With("user", "john").(errors.Error).Stack)
+}
+
+func (suite *ErrorsSuite) TestCanGetFrameSource() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	source, found := err.Stack[0].Source()
+	suite.Require().True(found, "The source of the test should be available")
+	suite.Assert().Equal("err := errors.NotImplemented.WithStack().(errors.Error)", source)
+
+	_, found = errors.StackFrame(0).Source()
+	suite.Assert().False(found)
+}
+
+func (suite *ErrorsSuite) TestCanShowSourceLines() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Assert().NotContains(fmt.Sprintf("%+v", err), "\n\t> ")
+
+	defer errors.ShowSourceLines(0)
+	errors.ShowSourceLines(1)
+	suite.Assert().Contains(fmt.Sprintf("%+v", err), "\n\t> err := errors.NotImplemented.WithStack().(errors.Error)")
+	suite.Assert().Len(regexp.MustCompile("\n\t> ").FindAllString(fmt.Sprintf("%+v", err), -1), 1)
+	suite.Assert().Contains(errors.ToHTML(err), `<pre class="source">err := errors.NotImplemented.WithStack().(errors.Error)</pre>`)
+}
+
+func (suite *ErrorsSuite) TestCanGetRuntimeFrames() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	frames := err.Stack.Frames()
+	frame, more := frames.Next()
+	suite.Assert().True(more)
+	suite.Assert().Equal("github.com/gildas/go-errors_test.(*ErrorsSuite).TestCanGetRuntimeFrames", frame.Function)
+	suite.Assert().Equal(err.Stack[0].Filepath(), frame.File)
+	suite.Assert().Equal(err.Stack[0].Line(), frame.Line)
+}
+
+func (suite *ErrorsSuite) TestCanConvertStackTraceToString() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	text := err.Stack.String()
+	suite.Assert().Regexp(`^github.com/gildas/go-errors_test.\(\*ErrorsSuite\).TestCanConvertStackTraceToString\n\t.*/stack_test.go:[0-9]+\n`, text)
+	suite.Assert().Equal(len(err.Stack), len(regexp.MustCompile(`(?m)^\t`).FindAllString(text, -1)))
+}
+
+func (suite *ErrorsSuite) TestCanGetFramePackage() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().NotEmpty(err.Stack, "The stack should not be empty")
+	frame := err.Stack[0]
+	suite.Assert().Equal("github.com/gildas/go-errors_test", frame.Package())
+	suite.Assert().Equal("go-errors_test.(*ErrorsSuite).TestCanGetFramePackage", frame.ShortFuncName())
+	suite.Assert().Equal("unknown", errors.StackFrame(0).Package())
+	suite.Assert().Equal("unknown", errors.StackFrame(0).ShortFuncName())
+}
+
+func (suite *ErrorsSuite) TestCanGetTopOfStackTrace() {
+	requireStack(suite.T())
+	err := errors.NotImplemented.WithStack().(errors.Error)
+	suite.Require().Greater(len(err.Stack), 2, "The stack should have more than 2 frames")
+	suite.Assert().Len(err.Stack.Top(2), 2)
+	suite.Assert().Equal(err.Stack[0], err.Stack.Top(1)[0])
+	suite.Assert().Len(err.Stack.Top(-1), len(err.Stack))
+	suite.Assert().Len(err.Stack.Top(100), len(err.Stack))
+	suite.Assert().Equal(2, len(regexp.MustCompile(`(?m)^\t`).FindAllString(fmt.Sprintf("%+.2v", err.Stack), -1)))
+	suite.Assert().Equal("[stack_test.go:176]", fmt.Sprintf("%.1v", err.Stack))
+}
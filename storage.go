@@ -0,0 +1,21 @@
+package errors
+
+// WithPath creates a new Error from a given Error about the file or the object at the given path
+//
+// The path goes to the What of the Error and to its "path" attribute.
+//
+// Example:
+//
+//	return errors.ChecksumMismatch.WithPath("bucket/report.pdf").WithSize(1024)
+func (e Error) WithPath(path string) Error {
+	final := e.WithField("path", path)
+	final.What = path
+	return final
+}
+
+// WithSize creates a new Error from a given Error with the size, in bytes, of the file or the object it is about
+//
+// The size goes to the "size" attribute of the Error.
+func (e Error) WithSize(size int64) Error {
+	return e.WithField("size", size)
+}
@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Throttler lets the same error through at most once per Window, see Throttle
+type Throttler struct {
+	// Window is the minimum duration between two reports of the same error
+	Window  time.Duration
+	entries map[string]*throttleEntry
+	lock    sync.Mutex
+}
+
+// throttleEntry tracks the occurrences of an error in the current window
+type throttleEntry struct {
+	until      time.Time
+	suppressed int
+}
+
+// throttleSweepSize is the number of tracked errors above which the expired ones are forgotten
+const throttleSweepSize = 1024
+
+var defaultThrottler = NewThrottler(0)
+
+// NewThrottler creates a new Throttler with the given window
+func NewThrottler(window time.Duration) *Throttler {
+	return &Throttler{Window: window, entries: map[string]*throttleEntry{}}
+}
+
+// Throttle lets the same error through at most once per window
+//
+// Throttle returns err the first time it sees it, then nil until the window is over.
+// When the error is let through again, the number of times it was suppressed
+// goes to its "suppressed" attribute.
+//
+// Errors are considered the same if they have the same ID and were created at the same place (the top of their Stack),
+// or, if they have no Stack, the same message. Other errors are the same if they have the same type and message.
+//
+// This allows hot loops that produce the same error thousands of times per second to report it at a bounded rate.
+//
+// The errors are tracked by a package-wide Throttler, use NewThrottler for a separate one.
+//
+// If err is nil, Throttle returns nil.
+//
+// Example:
+//
+//	for item := range queue {
+//	  if err := process(item); err != nil {
+//	    if err = errors.Throttle(err, 10*time.Second); err != nil {
+//	      log.Error("Failed to process item", "error", err)
+//	    }
+//	  }
+//	}
+func Throttle(err error, window time.Duration) error {
+	return defaultThrottler.throttle(err, window)
+}
+
+// Throttle lets the same error through at most once per Window of this Throttler
+//
+// See Throttle.
+func (throttler *Throttler) Throttle(err error) error {
+	return throttler.throttle(err, throttler.Window)
+}
+
+// throttle lets err through if it was not seen during the given window
+func (throttler *Throttler) throttle(err error, window time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	key := fingerprint(err)
+	now := time.Now()
+
+	throttler.lock.Lock()
+	defer throttler.lock.Unlock()
+	if throttler.entries == nil {
+		throttler.entries = map[string]*throttleEntry{}
+	}
+	entry, found := throttler.entries[key]
+	if found && now.Before(entry.until) {
+		entry.suppressed++
+		return nil
+	}
+	if !found && len(throttler.entries) >= throttleSweepSize {
+		for key, entry := range throttler.entries {
+			if !now.Before(entry.until) {
+				delete(throttler.entries, key)
+			}
+		}
+	}
+	throttler.entries[key] = &throttleEntry{until: now.Add(window)}
+	if !found || entry.suppressed == 0 {
+		return err
+	}
+	final, ok := asError(err)
+	if !ok {
+		final = Error{Origin: err}
+	}
+	return final.WithField("suppressed", entry.suppressed)
+}
+
+// fingerprint identifies the given error for Throttle
+func fingerprint(err error) string {
+	if details, ok := asError(err); ok {
+		if len(details.Stack) > 0 {
+			frame := details.Stack[0] // not its program counter, as it changes where the func is inlined
+			return details.ID + "@" + frame.Filepath() + ":" + strconv.Itoa(frame.Line())
+		}
+		return details.ID + ":" + details.Error()
+	}
+	return reflect.TypeOf(err).String() + ":" + err.Error()
+}
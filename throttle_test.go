@@ -0,0 +1,61 @@
+package errors_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+func lookupUser(name string) error {
+	return errors.NotFound.With("user", name)
+}
+
+func (suite *ErrorsSuite) TestCanThrottleErrors() {
+	requireStack(suite.T())
+	throttler := errors.NewThrottler(50 * time.Millisecond)
+	var reported []error
+	for attempt := 0; attempt < 100; attempt++ {
+		if err := throttler.Throttle(lookupUser("john")); err != nil {
+			reported = append(reported, err)
+		}
+	}
+	suite.Require().Len(reported, 1)
+	suite.Assert().ErrorIs(reported[0], errors.NotFound)
+	suite.Assert().NotContains(reported[0].(errors.Error).Attributes, "suppressed")
+
+	time.Sleep(2 * throttler.Window)
+	err := throttler.Throttle(lookupUser("jane"))
+	suite.Require().Error(err)
+	suite.Assert().Equal(99, err.(errors.Error).Attributes["suppressed"])
+	suite.Assert().Nil(throttler.Throttle(lookupUser("john")))
+	suite.Assert().Nil(throttler.Throttle(nil))
+}
+
+func (suite *ErrorsSuite) TestShouldThrottleErrorsIndependently() {
+	requireStack(suite.T())
+	throttler := errors.NewThrottler(time.Minute)
+	suite.Assert().Error(throttler.Throttle(errors.ArgumentMissing.With("name")))
+	suite.Assert().Error(throttler.Throttle(errors.ArgumentMissing.With("name")), "errors created at different places are different")
+
+	simple := fmt.Errorf("connection lost to %s", "db1")
+	suite.Assert().Equal(simple, throttler.Throttle(simple))
+	suite.Assert().Nil(throttler.Throttle(fmt.Errorf("connection lost to %s", "db1")))
+	suite.Assert().Error(throttler.Throttle(fmt.Errorf("connection lost to %s", "db2")))
+	suite.Assert().Error(errors.NewThrottler(time.Minute).Throttle(simple), "throttlers should not share their errors")
+}
+
+func (suite *ErrorsSuite) TestCanThrottleSimpleErrors() {
+	window := 20 * time.Millisecond
+	simple := fmt.Errorf("disk almost full (%d)", time.Now().UnixNano())
+	suite.Require().Error(errors.Throttle(simple, window))
+	suite.Require().Nil(errors.Throttle(simple, window))
+	time.Sleep(2 * window)
+	err := errors.Throttle(simple, window)
+	suite.Require().Error(err)
+	suite.Assert().Equal(simple.Error(), err.Error())
+	suite.Assert().ErrorIs(err, simple)
+	value, ok := errors.ValueAs[int](err, "suppressed")
+	suite.Assert().True(ok)
+	suite.Assert().Equal(1, value)
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Tree returns an indented tree of err and every error in its chain.
+//
+// Each Error is shown with its Code, ID, and message, MultiError members are shown as branches, like:
+//
+//	[500] error.creation.failed: Failed Creating user
+//	└── 2 errors
+//	    ├── [400] error.argument.missing: Argument name is missing
+//	    └── [404] error.notfound: group admins Not Found
+//
+// If the chain loops back on itself, the loop is shown as a CauseCycle error.
+//
+// If err is nil, Tree returns an empty string.
+func Tree(err error) string {
+	if err == nil {
+		return ""
+	}
+	var sb strings.Builder
+
+	writeTree(&sb, err, "", "", nil)
+	return sb.String()
+}
+
+// Tree returns an indented tree of this Error and every error in its chain.
+//
+// See Tree.
+func (e Error) Tree() string {
+	return Tree(e)
+}
+
+// writeTree writes the node of err and its branches in the given builder
+//
+// prefix is written before the node, indent before its branches, path contains the pointer errors that lead to err.
+func writeTree(sb *strings.Builder, err error, prefix, indent string, path []error) {
+	if isPointer(err) {
+		if slices.Contains(path, err) {
+			err = CauseCycle
+		} else {
+			path = append(path, err)
+		}
+	}
+	_, _ = sb.WriteString(prefix)
+	_, _ = sb.WriteString(treeLabel(err))
+	branches := branches(err)
+	for index, branch := range branches {
+		_, _ = sb.WriteString("\n")
+		if index < len(branches)-1 {
+			writeTree(sb, branch, indent+"├── ", indent+"│   ", path)
+		} else {
+			writeTree(sb, branch, indent+"└── ", indent+"    ", path)
+		}
+	}
+}
+
+// treeLabel returns the label of the node of err in a Tree
+func treeLabel(err error) string {
+	if multi, ok := err.(*MultiError); ok {
+		count := 0
+		if multi != nil {
+			count = len(multi.Errors)
+		}
+		if count == 1 {
+			return "1 error"
+		}
+		return strconv.Itoa(count) + " errors"
+	}
+	value, ok := asError(err)
+	if !ok {
+		label, _, _ := strings.Cut(err.Error(), "\n")
+		return label
+	}
+	var sb strings.Builder
+	if value.Code != 0 {
+		_, _ = sb.WriteString("[")
+		_, _ = sb.WriteString(strconv.Itoa(value.Code))
+		_, _ = sb.WriteString("] ")
+	}
+	if len(value.ID) > 0 {
+		_, _ = sb.WriteString(value.ID)
+		_, _ = sb.WriteString(": ")
+	}
+	_, _ = sb.WriteString(value.message())
+	return sb.String()
+}
@@ -0,0 +1,43 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanRenderTree() {
+	err := errors.CreationFailed.With("user").(errors.Error).
+		WithCause(errors.ArgumentMissing.With("name")).
+		WithCause(fmt.Errorf("lookup: %w", errors.NotFound.With("group", "admins")))
+	expected := `[500] error.creation.failed: Failed Creating user
+└── 2 errors
+    ├── [400] error.argument.missing: Argument name is missing
+    └── lookup: group admins Not Found
+        └── [404] error.notfound: group admins Not Found`
+	suite.Assert().Equal(expected, errors.Tree(err))
+	suite.Assert().Equal(expected, err.Tree())
+}
+
+func (suite *ErrorsSuite) TestCanRenderTreeWithNestedBranches() {
+	var errs errors.MultiError
+	errs.Append(
+		errors.CreationFailed.With("user").(errors.Error).WithCause(errors.Timeout.With("database")),
+		errors.ArgumentMissing.With("name"),
+	)
+	expected := `2 errors
+├── [500] error.creation.failed: Failed Creating user
+│   └── [408] error.timeout: database Timeout
+└── [400] error.argument.missing: Argument name is missing`
+	suite.Assert().Equal(expected, errors.Tree(&errs))
+}
+
+func (suite *ErrorsSuite) TestCanRenderTreeWithCycle() {
+	err := errors.NotFound.Clone()
+	err.Cause = err
+	suite.Assert().Contains(errors.Tree(err), "└── [500] error.cause.cycle: Circular cause detected")
+}
+
+func (suite *ErrorsSuite) TestShouldRenderEmptyTreeWithNilError() {
+	suite.Assert().Empty(errors.Tree(nil))
+}
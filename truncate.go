@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// TruncatedMarker is appended to the messages that were truncated, see SetMaxMessageLength
+const TruncatedMarker = "... (truncated)"
+
+var maxMessageLength atomic.Int64
+
+// SetMaxMessageLength sets the maximum number of characters of the messages rendered by Error() and MarshalJSON
+//
+// Longer messages are cut and followed by TruncatedMarker. In JSON, the "what" and string "value"
+// are cut and followed by "...", and "truncated" is true. The "text" is never cut, as it is a template.
+//
+// This protects log pipelines from errors that embed huge payloads, like request bodies, in their Value.
+//
+// A length of 0 or less means no limit, which is the default.
+func SetMaxMessageLength(length int) {
+	maxMessageLength.Store(int64(length))
+}
+
+// WithMaxLength creates a new Error from a given Error with its own maximum number of characters to render.
+//
+// This overrides the length set with SetMaxMessageLength. A length of 0 or less means no limit.
+func (e Error) WithMaxLength(length int) Error {
+	final := e.derive()
+	final.maxLength = max(length, -1)
+	if final.maxLength == 0 {
+		final.maxLength = -1
+	}
+	return final
+}
+
+// lengthLimit returns the maximum number of characters to render for this Error, 0 means no limit
+func (e Error) lengthLimit() int {
+	if e.maxLength != 0 {
+		return max(e.maxLength, 0)
+	}
+	if e.config != nil {
+		return max(e.config.MaxMessageLength, 0)
+	}
+	return int(max(maxMessageLength.Load(), 0))
+}
+
+// truncateText cuts the given text after the given number of characters
+//
+// truncateText returns true if the text was cut.
+func truncateText(text string, length int) (string, bool) {
+	if length <= 0 || len(text) <= length || utf8.RuneCountInString(text) <= length {
+		return text, false
+	}
+	count := 0
+	for index := range text {
+		if count == length {
+			return text[:index], true
+		}
+		count++
+	}
+	return text, false
+}
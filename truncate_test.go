@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestShouldTruncateLongMessages() {
+	defer errors.SetMaxMessageLength(0)
+	errors.SetMaxMessageLength(30)
+	body := strings.Repeat("é", 1000)
+	err := errors.ArgumentInvalid.With("body", body)
+	suite.Assert().Equal("Argument body is invalid (valu"+errors.TruncatedMarker, err.Error())
+	suite.Assert().Equal(body, err.(errors.Error).Value, "the Value itself should not be truncated")
+
+	err = errors.CreationFailed.With("user").(errors.Error).WithCause(err)
+	suite.Assert().Equal("Failed Creating user\nCaused by:\n\tArgument body is invalid (valu"+errors.TruncatedMarker, err.Error())
+
+	short := errors.NotFound.With("user", "john")
+	suite.Assert().Equal("user john Not Found", short.Error())
+}
+
+func (suite *ErrorsSuite) TestCanTruncateMessagesPerError() {
+	err := errors.ArgumentInvalid.WithMaxLength(10).With("body", strings.Repeat("x", 100))
+	suite.Assert().Equal("Argument b"+errors.TruncatedMarker, err.Error())
+
+	defer errors.SetMaxMessageLength(0)
+	errors.SetMaxMessageLength(10)
+	err = errors.ArgumentInvalid.WithMaxLength(0).With("body", "short")
+	suite.Assert().Equal("Argument body is invalid (value: short)", fmt.Sprintf("%v", err))
+}
+
+func (suite *ErrorsSuite) TestShouldTruncateLongMessagesInJSON() {
+	defer errors.SetMaxMessageLength(0)
+	errors.SetMaxMessageLength(40)
+	err := errors.ArgumentInvalid.With("body", strings.Repeat("x", 1000))
+	payload, jerr := json.Marshal(err)
+	suite.Require().NoError(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "body", "value": "`+strings.Repeat("x", 40)+`...", "truncated": true}`, string(payload))
+
+	payload, jerr = json.Marshal(errors.ArgumentInvalid.WithMaxLength(10).With(strings.Repeat("y", 100), "short"))
+	suite.Require().NoError(jerr)
+	suite.Assert().JSONEq(`{"type": "error", "version": 1, "id": "error.argument.invalid", "code": 400, "text": "Argument %s is invalid (value: %v)", "what": "`+strings.Repeat("y", 10)+`...", "value": "short", "truncated": true}`, string(payload), "the text should not be cut in the middle of its verbs")
+
+	payload, jerr = json.Marshal(errors.NotFound.With("user", "john"))
+	suite.Require().NoError(jerr)
+	suite.Assert().NotContains(string(payload), "truncated")
+}
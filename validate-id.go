@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"regexp"
+	"sync/atomic"
+)
+
+// DefaultIDPattern is the default naming scheme of error IDs
+//
+// IDs are dotted lower-case identifiers starting with "error", like: "error.<domain>.<reason>"
+var DefaultIDPattern = regexp.MustCompile(`^error(\.[a-z][a-z0-9_]*)+$`)
+
+var (
+	idPattern atomic.Pointer[regexp.Regexp]
+	strictIDs atomic.Bool
+)
+
+// SetIDPattern sets the naming scheme that ValidateID enforces
+//
+// If pattern is nil, DefaultIDPattern is used.
+func SetIDPattern(pattern *regexp.Regexp) {
+	idPattern.Store(pattern)
+}
+
+// StrictIDs tells if NewSentinel should validate the ID of new sentinels
+//
+// In strict mode, NewSentinel panics if the ID is not valid.
+//
+// By default, NewSentinel does not validate IDs.
+func StrictIDs(strict bool) {
+	strictIDs.Store(strict)
+}
+
+// ValidateID checks the given error ID against the naming scheme
+//
+// See SetIDPattern and DefaultIDPattern.
+func ValidateID(id string) error {
+	if pattern, ok := matchID(id); !ok {
+		return ArgumentInvalid.WithHint("IDs must match "+pattern.String()).With("id", id)
+	}
+	return nil
+}
+
+// matchID tells if the given error ID matches the current naming scheme
+//
+// It also returns the naming scheme that was used.
+func matchID(id string) (*regexp.Regexp, bool) {
+	pattern := idPattern.Load()
+	if pattern == nil {
+		pattern = DefaultIDPattern
+	}
+	return pattern, pattern.MatchString(id)
+}
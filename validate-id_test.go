@@ -0,0 +1,40 @@
+package errors_test
+
+import (
+	"regexp"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanValidateID() {
+	suite.Assert().Nil(errors.ValidateID("error.argument.invalid"))
+	suite.Assert().Nil(errors.ValidateID("error.client.not_connected"))
+	suite.Assert().Nil(errors.ValidateID("error.http"))
+
+	for _, id := range []string{"", "error", "Error.argument.invalid", "error.argument..invalid", "error.argument.invalid.", "argument.invalid", "error.Argument"} {
+		err := errors.ValidateID(id)
+		suite.Assert().ErrorIs(err, errors.ArgumentInvalid, "%q should not be valid", id)
+	}
+}
+
+func (suite *ErrorsSuite) TestCanValidateAllSentinelIDs() {
+	for sentinel := range errors.Sentinels() {
+		suite.Assert().Nil(errors.ValidateID(sentinel.ID), "%s should be valid", sentinel.ID)
+	}
+}
+
+func (suite *ErrorsSuite) TestCanValidateIDWithCustomPattern() {
+	errors.SetIDPattern(regexp.MustCompile(`^acme\.[a-z]+$`))
+	defer errors.SetIDPattern(nil)
+
+	suite.Assert().Nil(errors.ValidateID("acme.failure"))
+	suite.Assert().ErrorIs(errors.ValidateID("error.argument.invalid"), errors.ArgumentInvalid)
+}
+
+func (suite *ErrorsSuite) TestShouldPanicWithInvalidIDInStrictMode() {
+	errors.StrictIDs(true)
+	defer errors.StrictIDs(false)
+
+	suite.Assert().NotPanics(func() { _ = errors.NewSentinel(400, "error.test.strict.valid", "Valid") })
+	suite.Assert().Panics(func() { _ = errors.NewSentinel(400, "Test Strict Invalid", "Invalid") })
+}
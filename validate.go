@@ -0,0 +1,235 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationRule is a custom rule that ValidateStruct can use in "validate" struct tags
+//
+// Check receives the value of the field and the parameter of the rule in the tag, like "3" in "min=3",
+// and returns false if the value does not satisfy the rule.
+type ValidationRule struct {
+	Name  string
+	Check func(value interface{}, parameter string) bool
+}
+
+// ValidateStruct validates the fields of the given struct (or pointer to struct) with their "validate" tag
+//
+// The tag contains a comma-separated list of rules, the built-in rules are:
+//
+//	required   the field must not be its zero value
+//	omitempty  the other rules are skipped if the field is its zero value
+//	min=N      the length of strings, slices, and maps, or the value of numbers must be at least N
+//	max=N      the length of strings, slices, and maps, or the value of numbers must be at most N
+//	oneof=a b  the field must be one of the space-separated values
+//
+// Custom rules can be given, they override the built-in rules with the same name.
+//
+// Nested structs, pointers to structs, and slices of structs are validated too.
+// The fields are named after their json tag if any, like: "user.addresses[1].city".
+//
+// The first rule a field does not satisfy is reported as a ValidationFailed FieldError,
+// and all the FieldError are returned as a ValidationErrors, see ValidationErrors.AsError.
+// If all fields are valid, ValidateStruct returns nil.
+//
+// If the tag of a field uses an unknown rule, ValidateStruct returns an Unsupported error.
+//
+// Example:
+//
+//	type User struct {
+//	  Name  string `json:"name" validate:"required,max=64"`
+//	  Email string `json:"email" validate:"required,email"`
+//	}
+//
+//	err := errors.ValidateStruct(user, errors.ValidationRule{Name: "email", Check: func(value interface{}, _ string) bool {
+//	  return strings.Contains(value.(string), "@")
+//	}})
+func ValidateStruct(v interface{}, rules ...ValidationRule) error {
+	err := validateStruct(v, rules)
+	if final, ok := err.(Error); ok && len(final.Stack) > 0 {
+		final.Stack.capture(3) // skip extern.go, capture, this func, so the trace starts at the caller
+		return final
+	}
+	return err
+}
+
+// validateStruct validates the given struct, see ValidateStruct
+func validateStruct(v interface{}, rules []ValidationRule) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ArgumentMissing.With("struct")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return InvalidType.With(fmt.Sprintf("%T", v), "struct")
+	}
+	validator := structValidator{rules: map[string]func(reflect.Value, string) (bool, error){
+		"required": validateRequired,
+		"min":      validateMin,
+		"max":      validateMax,
+		"oneof":    validateOneOf,
+	}}
+	for _, rule := range rules {
+		check := rule.Check
+		validator.rules[rule.Name] = func(value reflect.Value, parameter string) (bool, error) {
+			return check(value.Interface(), parameter), nil
+		}
+	}
+	errs := &ValidationErrors{}
+	if err := validator.validateStruct(value, "", errs); err != nil {
+		return err
+	}
+	return errs.AsError()
+}
+
+// structValidator validates structs with the rules of their "validate" tags
+type structValidator struct {
+	rules map[string]func(value reflect.Value, parameter string) (bool, error)
+}
+
+// validateStruct validates the fields of the given struct value
+func (validator structValidator) validateStruct(value reflect.Value, path string, errs *ValidationErrors) error {
+	valueType := value.Type()
+	for index := 0; index < valueType.NumField(); index++ {
+		field := valueType.Field(index)
+		if !field.IsExported() {
+			continue
+		}
+		name := fieldName(field)
+		if len(name) == 0 {
+			continue
+		}
+		if len(path) > 0 {
+			name = path + "." + name
+		}
+		fieldValue := value.Field(index)
+		if tag, ok := field.Tag.Lookup("validate"); ok {
+			if err := validator.validateField(fieldValue, name, tag, errs); err != nil {
+				return err
+			}
+		}
+		if err := validator.validateNested(fieldValue, name, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNested validates the structs contained in the given value
+func (validator structValidator) validateNested(value reflect.Value, path string, errs *ValidationErrors) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		return validator.validateStruct(value, path, errs)
+	case reflect.Slice, reflect.Array:
+		switch value.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array:
+		default:
+			return nil // no struct to validate in there
+		}
+		for index := 0; index < value.Len(); index++ {
+			if err := validator.validateNested(value.Index(index), path+"["+strconv.Itoa(index)+"]", errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateField validates the given field value with the rules of the given tag
+//
+// Only the first failing rule is reported.
+func (validator structValidator) validateField(value reflect.Value, path, tag string, errs *ValidationErrors) error {
+	rules := strings.Split(tag, ",")
+	if slices.Contains(rules, "omitempty") && value.IsZero() {
+		return nil
+	}
+	for _, rule := range rules {
+		name, parameter, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if len(name) == 0 || name == "omitempty" {
+			continue
+		}
+		check, found := validator.rules[name]
+		if !found {
+			return Unsupported.With("validation rule", name)
+		}
+		valid, err := check(value, parameter)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			var fieldValue interface{}
+			if value.CanInterface() {
+				fieldValue = value.Interface()
+			}
+			errs.Add(path, name, fieldValue)
+			return nil
+		}
+	}
+	return nil
+}
+
+// fieldName gives the name of the given field, from its json tag if any
+//
+// fieldName returns an empty string for fields ignored by encoding/json.
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+func validateRequired(value reflect.Value, _ string) (bool, error) {
+	return !value.IsZero(), nil
+}
+
+func validateMin(value reflect.Value, parameter string) (bool, error) {
+	size, limit, err := measure(value, parameter)
+	return err == nil && size >= limit, err
+}
+
+func validateMax(value reflect.Value, parameter string) (bool, error) {
+	size, limit, err := measure(value, parameter)
+	return err == nil && size <= limit, err
+}
+
+func validateOneOf(value reflect.Value, parameter string) (bool, error) {
+	return slices.Contains(strings.Fields(parameter), fmt.Sprint(value.Interface())), nil
+}
+
+// measure gives the length or the numerical value of the given value and the given limit
+func measure(value reflect.Value, parameter string) (float64, float64, error) {
+	limit, err := strconv.ParseFloat(parameter, 64)
+	if err != nil {
+		return 0, 0, ArgumentInvalid.With("limit", parameter)
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), limit, nil
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return float64(value.Len()), limit, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), limit, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), limit, nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), limit, nil
+	}
+	return 0, 0, Unsupported.With("value type", value.Type().String())
+}
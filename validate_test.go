@@ -0,0 +1,75 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+type validatedAddress struct {
+	City    string `json:"city" validate:"required"`
+	Country string `json:"country" validate:"oneof=FR US JP"`
+}
+
+type validatedUser struct {
+	Name      string             `json:"name" validate:"required,max=8"`
+	Email     string             `json:"email" validate:"omitempty,email"`
+	Age       int                `json:"age" validate:"min=18"`
+	Tags      []string           `json:"tags" validate:"max=2"`
+	Addresses []validatedAddress `json:"addresses"`
+	Ignored   string             `json:"-" validate:"required"`
+}
+
+var emailRule = errors.ValidationRule{Name: "email", Check: func(value interface{}, _ string) bool {
+	return strings.Contains(value.(string), "@")
+}}
+
+func (suite *ErrorsSuite) TestCanValidateStruct() {
+	user := validatedUser{Name: "john", Email: "john@acme.com", Age: 32, Addresses: []validatedAddress{{City: "Paris", Country: "FR"}}}
+	suite.Assert().NoError(errors.ValidateStruct(user, emailRule))
+	suite.Assert().NoError(errors.ValidateStruct(&user, emailRule))
+
+	user = validatedUser{Email: "", Age: 32}
+	err := errors.ValidateStruct(user, emailRule)
+	suite.Require().Error(err)
+	suite.Assert().Equal("name", err.(errors.Error).What, "omitempty should skip the empty email")
+}
+
+func (suite *ErrorsSuite) TestCanValidateStructWithFieldErrors() {
+	requireStack(suite.T())
+	user := validatedUser{
+		Name:      "johnathan smith",
+		Email:     "john",
+		Age:       12,
+		Tags:      []string{"a", "b", "c"},
+		Addresses: []validatedAddress{{City: "Paris", Country: "FR"}, {Country: "DE"}},
+	}
+	err := errors.ValidateStruct(&user, emailRule)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ValidationFailed)
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal(422, details.Code)
+	suite.Assert().Equal("name, email, age, tags, addresses[1].city, addresses[1].country", details.What)
+	suite.Require().NotEmpty(details.Stack)
+	suite.Assert().Equal("(*ErrorsSuite).TestCanValidateStructWithFieldErrors", fmt.Sprintf("%n", details.Stack[0]))
+
+	var fieldError errors.FieldError
+	suite.Require().ErrorAs(err, &fieldError)
+	suite.Assert().Equal("name", fieldError.Field)
+	suite.Assert().Equal("max", fieldError.Rule)
+	suite.Assert().Equal("johnathan smith", fieldError.Value)
+}
+
+func (suite *ErrorsSuite) TestShouldFailValidatingWithUnknownRule() {
+	err := errors.ValidateStruct(validatedUser{Name: "john", Email: "john@acme.com"})
+	suite.Assert().ErrorIs(err, errors.Unsupported)
+	suite.Assert().NotErrorIs(err, errors.ValidationFailed)
+
+	err = errors.ValidateStruct(12)
+	suite.Assert().ErrorIs(err, errors.InvalidType)
+
+	err = errors.ValidateStruct((*validatedUser)(nil))
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+}
@@ -0,0 +1,171 @@
+package errors
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// FieldError describes a field that does not satisfy a validation rule
+type FieldError struct {
+	// Field is the path of the field, like: "user.email"
+	Field string `json:"field"`
+	// Pointer is the JSON Pointer (RFC 6901) of the field, like: "/user/email"
+	Pointer string `json:"pointer,omitempty"`
+	// Rule is the validation rule the field does not satisfy, like: "format" or "required"
+	Rule string `json:"rule,omitempty"`
+	// Value is the value of the field, if any
+	Value interface{} `json:"value,omitempty"`
+	// Message is the human readable message
+	Message string `json:"message,omitempty"`
+	// sentinel is the Error this FieldError was created from, see ForField
+	sentinel Error
+}
+
+// ForField creates a new FieldError from a given sentinel for the given field, rule, and value
+//
+// The field is the What of the sentinel when rendering the message.
+// Its JSON Pointer is computed from the path, like: "/items/3/price" for "items[3].price".
+//
+// Example:
+//
+//	errs := &errors.ValidationErrors{}
+//	if !strings.Contains(user.Email, "@") {
+//	  errs.Append(errors.ValidationFailed.ForField("user.email", "format", user.Email))
+//	}
+func (e Error) ForField(field, rule string, value interface{}) FieldError {
+	final := e.derive()
+	final.What = field
+	final.Value = value
+	return FieldError{
+		Field:    field,
+		Pointer:  pathToPointer(field),
+		Rule:     rule,
+		Value:    value,
+		Message:  final.message(),
+		sentinel: e,
+	}
+}
+
+// Error returns the string version of this error
+//
+// implements error interface
+func (e FieldError) Error() string {
+	message := e.Message
+	if len(message) == 0 {
+		message = "Validation failed for " + e.Field
+	}
+	if len(e.Rule) == 0 {
+		return message
+	}
+	return message + " (rule: " + e.Rule + ")"
+}
+
+// Is tells if this error matches the target
+//
+// A FieldError matches the sentinel it was created from, or ValidationFailed.
+//
+// implements errors.Is interface (package "errors").
+func (e FieldError) Is(target error) bool {
+	if len(e.sentinel.ID) == 0 {
+		return ValidationFailed.Is(target)
+	}
+	return e.sentinel.Is(target)
+}
+
+// ValidationErrors collects the FieldError of a validation
+//
+// The errors are rendered as an HTTP 422 (Unprocessable Entity) ValidationFailed Error by AsError.
+type ValidationErrors struct {
+	MultiError
+}
+
+// Unwrap gives the errors of this
+//
+// implements errors.Unwrap interface (package "errors").
+func (ve *ValidationErrors) Unwrap() []error {
+	if ve == nil {
+		return nil
+	}
+	return ve.Errors
+}
+
+// Add appends a ValidationFailed FieldError for the given field, rule, and value
+func (ve *ValidationErrors) Add(field, rule string, value interface{}) {
+	ve.Append(ValidationFailed.ForField(field, rule, value))
+}
+
+// FieldErrors gives the FieldError of this, in the order they were added
+//
+// Errors that are not a FieldError are ignored.
+func (ve *ValidationErrors) FieldErrors() []FieldError {
+	if ve == nil {
+		return nil
+	}
+	fieldErrors := make([]FieldError, 0, len(ve.Errors))
+	for _, err := range ve.Errors {
+		var fieldError FieldError
+		if As(err, &fieldError) {
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+	}
+	return fieldErrors
+}
+
+// ByField gives the FieldError of this grouped by their Field
+func (ve *ValidationErrors) ByField() map[string][]FieldError {
+	groups := map[string][]FieldError{}
+	for _, fieldError := range ve.FieldErrors() {
+		groups[fieldError.Field] = append(groups[fieldError.Field], fieldError)
+	}
+	return groups
+}
+
+// AsError returns a ValidationFailed Error if this contains errors, nil otherwise
+//
+// The Error has the HTTP Code 422, its What lists the invalid fields,
+// its "fields" attribute contains the FieldError grouped by field, and its Cause is this.
+//
+// AsError also records the stack trace at the point it was called.
+func (ve *ValidationErrors) AsError() error {
+	if ve == nil || ve.IsEmpty() {
+		return nil
+	}
+	groups := ve.ByField()
+	fields := make([]string, 0, len(groups))
+	for _, fieldError := range ve.FieldErrors() {
+		if !slices.Contains(fields, fieldError.Field) {
+			fields = append(fields, fieldError.Field)
+		}
+	}
+	final := ValidationFailed.derive()
+	final.What = strings.Join(fields, ", ")
+	final.Attributes = map[string]interface{}{"fields": groups}
+	final.Cause = ve
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// MarshalJSON marshals this into JSON
+//
+// The FieldError are grouped by field, like:
+//
+//	{"type": "validation", "code": 422, "id": "error.validation.failed", "fields": {"user.email": [{"field": "user.email", "rule": "format"}]}}
+func (ve *ValidationErrors) MarshalJSON() ([]byte, error) {
+	payload := struct {
+		Type   string                  `json:"type"`
+		Code   int                     `json:"code"`
+		ID     string                  `json:"id"`
+		Text   string                  `json:"text"`
+		Fields map[string][]FieldError `json:"fields"`
+	}{
+		Type:   "validation",
+		Code:   ValidationFailed.Code,
+		ID:     ValidationFailed.ID,
+		Text:   ve.Error(),
+		Fields: ve.ByField(),
+	}
+	data, err := json.Marshal(payload)
+	return data, JSONMarshalError.Wrap(err)
+}
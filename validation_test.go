@@ -0,0 +1,76 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanCreateFieldError() {
+	err := errors.ValidationFailed.ForField("user.email", "format", "john")
+	suite.Assert().Equal("user.email", err.Field)
+	suite.Assert().Equal("format", err.Rule)
+	suite.Assert().Equal("john", err.Value)
+	suite.Assert().Equal("Validation failed for user.email (rule: format)", err.Error())
+	suite.Assert().ErrorIs(err, errors.ValidationFailed)
+	suite.Assert().NotErrorIs(err, errors.NotFound)
+
+	other := errors.ArgumentMissing.ForField("user.name", "required", nil)
+	suite.Assert().Equal("Argument user.name is missing (rule: required)", other.Error())
+	suite.Assert().ErrorIs(other, errors.ArgumentMissing)
+}
+
+func (suite *ErrorsSuite) TestCanCollectValidationErrors() {
+	requireStack(suite.T())
+	errs := &errors.ValidationErrors{}
+	suite.Assert().Nil(errs.AsError())
+
+	errs.Add("user.email", "format", "john")
+	errs.Append(errors.ArgumentMissing.ForField("user.name", "required", nil))
+	errs.Add("user.email", "maxlength", "john")
+
+	suite.Require().Len(errs.FieldErrors(), 3)
+	groups := errs.ByField()
+	suite.Assert().Len(groups["user.email"], 2)
+	suite.Assert().Len(groups["user.name"], 1)
+
+	err := errs.AsError()
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ValidationFailed)
+	suite.Assert().True(errors.IsCode(err, http.StatusUnprocessableEntity))
+	details, ok := err.(errors.Error)
+	suite.Require().True(ok, "err should be an errors.Error")
+	suite.Assert().Equal("user.email, user.name", details.What)
+	suite.Assert().NotEmpty(details.Stack)
+
+	var fieldError errors.FieldError
+	suite.Require().ErrorAs(err, &fieldError)
+	suite.Assert().Equal("user.email", fieldError.Field)
+}
+
+func (suite *ErrorsSuite) TestCanMarshalValidationErrors() {
+	errs := &errors.ValidationErrors{}
+	errs.Add("user.email", "format", "john")
+	errs.Add("user.age", "min", 12)
+
+	payload, err := json.Marshal(errs)
+	suite.Require().NoError(err)
+	expected := `{
+		"type": "validation",
+		"code": 422,
+		"id": "error.validation.failed",
+		"text": "2 errors:\nValidation failed for user.email (rule: format)\nValidation failed for user.age (rule: min)",
+		"fields": {
+			"user.email": [{"field": "user.email", "pointer": "/user/email", "rule": "format", "value": "john", "message": "Validation failed for user.email"}],
+			"user.age": [{"field": "user.age", "pointer": "/user/age", "rule": "min", "value": 12, "message": "Validation failed for user.age"}]
+		}
+	}`
+	suite.Assert().JSONEq(expected, string(payload))
+}
+
+func (suite *ErrorsSuite) TestCanConvertNilValidationErrors() {
+	var errs *errors.ValidationErrors
+	suite.Assert().NotPanics(func() { suite.Assert().Nil(errs.AsError()) })
+	suite.Assert().Nil((&errors.ValidationErrors{}).AsError())
+}
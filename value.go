@@ -0,0 +1,104 @@
+package errors
+
+import "reflect"
+
+// ValueAs finds the first Error in err's chain that has an attribute with the given key
+// and returns its value if it is of type T.
+//
+// Example:
+//
+//	if tenant, ok := errors.ValueAs[string](err, "tenant"); ok {
+//	  // do something with tenant
+//	}
+func ValueAs[T any](err error, key string) (T, bool) {
+	var typed T
+	var found bool
+	Walk(err, func(err error) bool {
+		if details, ok := asError(err); ok {
+			if value, exists := details.Attributes[key]; exists {
+				typed, found = value.(T)
+				return false
+			}
+		}
+		return true
+	})
+	return typed, found
+}
+
+// WithTypedValue creates a new Error from a given sentinel telling "what" is wrong and its statically typed value.
+//
+// The value can be retrieved with TypedValueOf without any type assertion.
+//
+// WithTypedValue also records the stack trace at the point it was called.
+//
+// Example:
+//
+//	return errors.WithTypedValue(errors.ArgumentInvalid, "page", page)
+func WithTypedValue[T any](sentinel Error, what string, value T) error {
+	final := sentinel.derive()
+	final.What = what
+	final.Value = value
+	final.initializeStack()
+	final.enrich()
+	return final
+}
+
+// TypedValueOf finds the first Error in err's chain that has a Value of type T and returns it.
+//
+// Example:
+//
+//	if page, ok := errors.TypedValueOf[int](err); ok {
+//	  // do something with page
+//	}
+func TypedValueOf[T any](err error) (T, bool) {
+	var typed T
+	var found bool
+	Walk(err, func(err error) bool {
+		if details, ok := asError(err); ok && details.Value != nil {
+			typed, found = details.Value.(T)
+		}
+		return !found
+	})
+	return typed, found
+}
+
+// ValueAs stores the Value of this Error in the given target if their types are compatible
+//
+// target must be a non-nil pointer.
+//
+// ValueAs returns true if the target was set.
+//
+// Example:
+//
+//	var value int
+//	if details.ValueAs(&value) {
+//	  // do something with value
+//	}
+func (e Error) ValueAs(target interface{}) bool {
+	if e.Value == nil || target == nil {
+		return false
+	}
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() {
+		return false
+	}
+	value := reflect.ValueOf(e.Value)
+	if !value.Type().AssignableTo(pointer.Elem().Type()) {
+		return false
+	}
+	pointer.Elem().Set(value)
+	return true
+}
+
+// asError converts the given error into an Error if it is one
+func asError(err error) (Error, bool) {
+	switch actual := err.(type) {
+	case Error:
+		return actual, true
+	case *Error:
+		if actual != nil {
+			return *actual, true
+		}
+	}
+	return Error{}, false
+}
@@ -0,0 +1,81 @@
+package errors_test
+
+import (
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanGetTypedAttribute() {
+	err := errors.WrapErrors(
+		errors.ArgumentInvalid.WithField("attempt", 3).With("key", "value"),
+		errors.NotFound.WithFields(map[string]interface{}{"tenant": "acme", "attempt": 4}).With("user", "john"),
+	)
+
+	attempt, ok := errors.ValueAs[int](err, "attempt")
+	suite.Require().True(ok, "err should have an attempt attribute")
+	suite.Assert().Equal(3, attempt, "the first attribute in the chain should win")
+
+	tenant, ok := errors.ValueAs[string](err, "tenant")
+	suite.Require().True(ok, "err should have a tenant attribute")
+	suite.Assert().Equal("acme", tenant)
+
+	_, ok = errors.ValueAs[string](err, "attempt")
+	suite.Assert().False(ok, "attempt should not be a string")
+
+	_, ok = errors.ValueAs[string](err, "unknown")
+	suite.Assert().False(ok, "err should not have an unknown attribute")
+
+	_, ok = errors.ValueAs[string](fmt.Errorf("simple error"), "tenant")
+	suite.Assert().False(ok, "simple errors do not have attributes")
+}
+
+func (suite *ErrorsSuite) TestCanGetTypedValue() {
+	var details *errors.Error
+	suite.Require().ErrorAs(errors.ArgumentInvalid.With("page", 500), &details)
+
+	var value int
+	suite.Require().True(details.ValueAs(&value), "value should be an int")
+	suite.Assert().Equal(500, value)
+
+	var anything interface{}
+	suite.Require().True(details.ValueAs(&anything), "value should be assignable to interface{}")
+	suite.Assert().Equal(500, anything)
+
+	var text string
+	suite.Assert().False(details.ValueAs(&text), "value should not be a string")
+	suite.Assert().False(details.ValueAs(value), "target should be a pointer")
+	suite.Assert().False(errors.ArgumentMissing.ValueAs(&value), "ArgumentMissing has no value")
+}
+
+func (suite *ErrorsSuite) TestCanGetValueOfTypedError() {
+	requireStack(suite.T())
+	type Page struct{ Number, Size int }
+	err := errors.WithTypedValue(errors.ArgumentInvalid, "page", Page{Number: 5, Size: 500})
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+	suite.Assert().Equal("page", err.(errors.Error).What)
+	suite.Assert().NotEmpty(err.(errors.Error).Stack)
+
+	wrapped := errors.WrapErrors(errors.ArgumentInvalid.With("name", "john"), err)
+	page, ok := errors.TypedValueOf[Page](wrapped)
+	suite.Require().True(ok, "err should have a Page value")
+	suite.Assert().Equal(500, page.Size)
+
+	name, ok := errors.TypedValueOf[string](wrapped)
+	suite.Require().True(ok, "err should have a string value")
+	suite.Assert().Equal("john", name)
+
+	_, ok = errors.TypedValueOf[float64](wrapped)
+	suite.Assert().False(ok, "err should not have a float64 value")
+	_, ok = errors.TypedValueOf[Page](fmt.Errorf("simple error"))
+	suite.Assert().False(ok, "simple errors do not have values")
+}
+
+func (suite *ErrorsSuite) TestCanGetTypedValueWithFrozenSentinels() {
+	errors.FreezeSentinels(true)
+	defer errors.FreezeSentinels(false)
+
+	err := errors.WithTypedValue(errors.ArgumentInvalid, "page", 5)
+	suite.Assert().NotPanics(func() { _ = err.(errors.Error).WithField("key", "value") })
+	suite.Assert().Empty(errors.ArgumentInvalid.What, "the sentinel should not have changed")
+}
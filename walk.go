@@ -0,0 +1,132 @@
+package errors
+
+import "slices"
+
+// Walk visits err and every error in its chain, depth first.
+//
+// The chain includes the errors of MultiError and of errors implementing Unwrap() []error.
+//
+// The Origin of an Error is not visited, as the Error represents it.
+//
+// If the chain loops back on itself, the loop is not followed.
+//
+// Walk stops as soon as the visitor returns false.
+//
+// Walk returns false if the visitor stopped the walk.
+//
+// Example:
+//
+//	errors.Walk(err, func(err error) bool {
+//	  fmt.Println(err)
+//	  return true
+//	})
+func Walk(err error, visitor func(err error) bool) bool {
+	return walk(err, visitor, nil)
+}
+
+// walk visits err and its chain, path contains the pointer errors that lead to err
+func walk(err error, visitor func(err error) bool, path []error) bool {
+	if err == nil {
+		return true
+	}
+	if isPointer(err) {
+		if slices.Contains(path, err) {
+			return true
+		}
+		path = append(path, err)
+	}
+	if !visitor(err) {
+		return false
+	}
+	for _, branch := range branches(err) {
+		if !walk(branch, visitor, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// branches returns the errors directly wrapped by err
+func branches(err error) []error {
+	switch actual := err.(type) {
+	case *MultiError:
+		if actual == nil {
+			return nil
+		}
+		return actual.Errors
+	case interface{ Unwrap() []error }:
+		return actual.Unwrap()
+	case interface{ Unwrap() error }:
+		if inner := actual.Unwrap(); inner != nil {
+			return []error{inner}
+		}
+	}
+	return nil
+}
+
+// Chain returns err followed by every error in its chain, in order.
+//
+// The errors of MultiError are expanded in place of the MultiError.
+//
+// If err is nil, Chain returns nil.
+func Chain(err error) []error {
+	var chain []error
+	Walk(err, func(err error) bool {
+		if _, ok := err.(*MultiError); !ok {
+			chain = append(chain, err)
+		}
+		return true
+	})
+	return chain
+}
+
+// Chain returns this Error followed by every error in its chain, in order.
+//
+// See Chain.
+func (e Error) Chain() []error {
+	return Chain(e)
+}
+
+// ExtractAll returns every error of type T in err's chain, in order.
+//
+// Unlike As, which stops at the first match, ExtractAll collects all the matches,
+// including the ones in every branch of MultiError.
+//
+// If T is Error, the *Error in the chain are extracted as well.
+//
+// Example:
+//
+//	for _, details := range errors.ExtractAll[errors.Error](err) {
+//	  fmt.Println(details.ID)
+//	}
+func ExtractAll[T error](err error) []T {
+	var matches []T
+	Walk(err, func(err error) bool {
+		if typed, ok := err.(T); ok {
+			matches = append(matches, typed)
+		} else if pointer, ok := err.(*Error); ok && pointer != nil {
+			if typed, ok := any(*pointer).(T); ok {
+				matches = append(matches, typed)
+			}
+		}
+		return true
+	})
+	return matches
+}
+
+// ExtractAllByID returns every Error with the given ID in err's chain, in order.
+//
+// Example:
+//
+//	for _, invalid := range errors.ExtractAllByID(err, errors.ArgumentInvalid.ID) {
+//	  report(invalid.What, invalid.Value)
+//	}
+func ExtractAllByID(err error, id string) []Error {
+	var matches []Error
+	for _, details := range ExtractAll[Error](err) {
+		if details.ID == id {
+			matches = append(matches, details)
+		}
+	}
+	return matches
+}
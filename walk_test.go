@@ -0,0 +1,94 @@
+package errors_test
+
+import (
+	goerrors "errors"
+	"fmt"
+
+	"github.com/gildas/go-errors"
+)
+
+func (suite *ErrorsSuite) TestCanWalk() {
+	var errs errors.MultiError
+	errs.Append(
+		errors.ArgumentMissing.With("name"),
+		errors.WrapErrors(errors.NotFound.With("user", "john"), errors.Timeout.With("database")),
+	)
+	err := errors.Wrap(errs.AsError(), "Failed")
+
+	ids := []string{}
+	completed := errors.Walk(err, func(err error) bool {
+		if details, ok := err.(errors.Error); ok {
+			ids = append(ids, details.ID)
+		} else {
+			ids = append(ids, fmt.Sprintf("%T", err))
+		}
+		return true
+	})
+	suite.Assert().True(completed)
+	suite.Assert().Equal([]string{"error.runtime", "error.multiple", "*errors.MultiError", "error.argument.missing", "error.notfound", "error.timeout"}, ids)
+}
+
+func (suite *ErrorsSuite) TestCanWalkMultiUnwrapErrors() {
+	err := goerrors.Join(errors.ArgumentMissing.With("name"), fmt.Errorf("wrapped: %w", errors.NotFound.With("user", "john")))
+
+	count := 0
+	suite.Assert().True(errors.Walk(err, func(err error) bool {
+		count++
+		return true
+	}))
+	suite.Assert().Equal(4, count)
+}
+
+func (suite *ErrorsSuite) TestCanStopWalking() {
+	err := errors.WrapErrors(errors.ArgumentMissing.With("name"), errors.NotFound.With("user", "john"), errors.Timeout.With("database"))
+
+	visited := []error{}
+	completed := errors.Walk(err, func(err error) bool {
+		visited = append(visited, err)
+		return err.(errors.Error).ID != errors.NotFound.ID
+	})
+	suite.Assert().False(completed)
+	suite.Assert().Len(visited, 2)
+	suite.Assert().True(errors.Walk(nil, func(err error) bool { return false }))
+}
+
+func (suite *ErrorsSuite) TestCanGetChain() {
+	var errs errors.MultiError
+	errs.Append(errors.ArgumentMissing.With("name"), fmt.Errorf("simple error"))
+	err := errors.WrapErrors(errors.NotFound.With("user", "john"), errs.AsError())
+
+	chain := errors.Chain(err)
+	suite.Require().Len(chain, 4)
+	suite.Assert().ErrorIs(chain[0], errors.NotFound)
+	suite.Assert().Equal(errors.MultipleErrors.ID, chain[1].(errors.Error).ID, "AsError wraps the MultiError")
+	suite.Assert().ErrorIs(chain[2], errors.ArgumentMissing)
+	suite.Assert().Equal("simple error", chain[3].Error())
+
+	suite.Assert().Equal(chain, err.(errors.Error).Chain())
+	suite.Assert().Nil(errors.Chain(nil))
+	suite.Assert().Len(errors.NotFound.Chain(), 1)
+}
+
+func (suite *ErrorsSuite) TestCanExtractAllErrors() {
+	var errs errors.MultiError
+	errs.Append(
+		errors.ArgumentInvalid.With("name", ""),
+		fmt.Errorf("handler: %w", errors.ArgumentInvalid.With("age", -1)),
+		&errors.Error{ID: errors.ArgumentInvalid.ID, What: "email"},
+		errors.NotFound.With("user", "john"),
+	)
+	err := errors.CreationFailed.Wrap(&errs)
+
+	all := errors.ExtractAll[errors.Error](err)
+	suite.Assert().Len(all, 5)
+
+	invalids := errors.ExtractAllByID(err, errors.ArgumentInvalid.ID)
+	suite.Require().Len(invalids, 3)
+	suite.Assert().Equal("name", invalids[0].What)
+	suite.Assert().Equal("age", invalids[1].What)
+	suite.Assert().Equal("email", invalids[2].What)
+
+	suite.Assert().Len(errors.ExtractAll[*errors.MultiError](err), 1)
+	suite.Assert().Empty(errors.ExtractAll[*errors.MultiError](errors.NotFound.With("user", "john")))
+	suite.Assert().Empty(errors.ExtractAllByID(nil, errors.ArgumentInvalid.ID))
+}
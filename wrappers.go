@@ -81,6 +81,8 @@ func Wrapf(err error, format string, args ...interface{}) error {
 // If one of the errors in the middle of the chain is nil, that error is ignored.
 //
 // If there is only one error in the chain, WrapErrors returns it.
+//
+// To wrap the errors even if the first or the last one is nil, use WrapAll.
 func WrapErrors(errors ...error) error {
 	if len(errors) == 0 || errors[0] == nil || errors[len(errors)-1] == nil {
 		return nil
@@ -92,11 +94,11 @@ func WrapErrors(errors ...error) error {
 	createContainer := func(err error) Error {
 		container, ok := err.(Error)
 		if !ok {
-			container = RuntimeError
+			container = RuntimeError.derive()
 			container.Origin = err
 			container.Text = err.Error()
 		}
-		return container
+		return container.derive()
 	}
 
 	index := len(errors) - 1
@@ -112,6 +114,27 @@ func WrapErrors(errors ...error) error {
 	return container
 }
 
+// WrapAll returns an error wrapping the given errors, ignoring the nil ones
+//
+// Unlike WrapErrors, WrapAll does not return nil if the first or the last error is nil,
+// it wraps whatever errors remain.
+//
+// If all the errors are nil, WrapAll returns nil.
+//
+// If there is only one error that is not nil, WrapAll returns it.
+func WrapAll(errors ...error) error {
+	remaining := make([]error, 0, len(errors))
+	for _, err := range errors {
+		if err != nil {
+			remaining = append(remaining, err)
+		}
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	return WrapErrors(remaining...)
+}
+
 // Join returns an error wrapping given errors
 //
 // If the first or the last error in the chain is nil, Join returns nil.
@@ -130,11 +153,11 @@ func Join(errors ...error) error {
 	createContainer := func(err error) Error {
 		container, ok := err.(Error)
 		if !ok {
-			container = RuntimeError
+			container = RuntimeError.derive()
 			container.Origin = err
 			container.Text = err.Error()
 		}
-		return container
+		return container.derive()
 	}
 
 	index := len(errors) - 1
@@ -170,6 +193,39 @@ func WithMessagef(err error, format string, args ...interface{}) error {
 	return Error{Code: http.StatusInternalServerError, ID: "error.runtime", Text: fmt.Sprintf(format, args...)}.Wrap(err)
 }
 
+// RootCause returns the deepest error in err's chain, i.e. the first one that does not wrap another error.
+//
+// The chain is followed with Unwrap() error and, for github.com/pkg/errors compatibility, with Cause() error.
+//
+// Errors that wrap several errors, like MultiError, are considered the root cause.
+//
+// If err is nil, RootCause returns nil.
+func RootCause(err error) error {
+	for err != nil {
+		var inner error
+		switch actual := err.(type) {
+		case interface{ Unwrap() error }:
+			inner = actual.Unwrap()
+		case interface{ Cause() error }:
+			inner = actual.Cause()
+		}
+		if inner == nil {
+			return err
+		}
+		err = inner
+	}
+	return nil
+}
+
+// Cause returns the underlying cause of the error, if possible.
+//
+// This is the same as RootCause and provides compatibility with github.com/pkg/errors.
+//
+// Note: Error cannot have a Cause() method as it already has a Cause field.
+func Cause(err error) error {
+	return RootCause(err)
+}
+
 //***************** goerrors
 
 // Is reports whether any error in err's chain matches target.
@@ -183,6 +239,33 @@ func Is(err, target error) bool {
 	return goerrors.Is(err, target)
 }
 
+// IsAny reports whether any error in err's chain matches any of the targets.
+//
+// IsAny returns false if there are no targets.
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAll reports whether err's chain matches all of the targets.
+//
+// IsAll returns false if err is nil or if there are no targets.
+func IsAll(err error, targets ...error) bool {
+	if err == nil || len(targets) == 0 {
+		return false
+	}
+	for _, target := range targets {
+		if !Is(err, target) {
+			return false
+		}
+	}
+	return true
+}
+
 // Unwrap returns the result of calling the Unwrap method on err, if err's
 // type contains an Unwrap method returning error.
 //
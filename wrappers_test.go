@@ -61,3 +61,68 @@ func (suite *ErrorsSuite) TestWrappers() {
 	var inner *errors.Error
 	suite.Assert().True(errors.As(err, &inner), "Inner Error should be an errors.Error")
 }
+
+func (suite *ErrorsSuite) TestCanCheckIsAny() {
+	err := errors.WrapErrors(errors.ArgumentMissing.With("name"), errors.NotFound.With("user", "john"))
+	suite.Assert().True(errors.IsAny(err, errors.HTTPNotFound, errors.NotFound))
+	suite.Assert().True(errors.IsAny(err, errors.ArgumentMissing))
+	suite.Assert().False(errors.IsAny(err, errors.HTTPNotFound, errors.ArgumentInvalid))
+	suite.Assert().False(errors.IsAny(err))
+	suite.Assert().False(errors.IsAny(nil, errors.NotFound))
+}
+
+func (suite *ErrorsSuite) TestCanCheckIsAll() {
+	err := errors.WrapErrors(errors.ArgumentMissing.With("name"), errors.NotFound.With("user", "john"))
+	suite.Assert().True(errors.IsAll(err, errors.ArgumentMissing, errors.NotFound))
+	suite.Assert().False(errors.IsAll(err, errors.ArgumentMissing, errors.HTTPNotFound))
+	suite.Assert().False(errors.IsAll(err))
+	suite.Assert().False(errors.IsAll(nil, errors.NotFound))
+}
+
+type causer struct {
+	cause error
+}
+
+func (c causer) Error() string { return "causer: " + c.cause.Error() }
+func (c causer) Cause() error  { return c.cause }
+
+func (suite *ErrorsSuite) TestCanGetRootCause() {
+	root := fmt.Errorf("root error")
+	err := errors.WrapErrors(errors.ArgumentMissing.With("name"), errors.NotFound.With("user", "john"), root)
+	suite.Assert().Equal(root, errors.RootCause(err))
+	suite.Assert().Equal(root, errors.Cause(err))
+
+	err = errors.NotImplemented.Wrap(causer{cause: fmt.Errorf("wrapped: %w", root)})
+	suite.Assert().Equal(root, errors.RootCause(err))
+
+	suite.Assert().Equal(errors.NotImplemented, errors.RootCause(errors.NotImplemented))
+	suite.Assert().Nil(errors.RootCause(nil))
+}
+
+func (suite *ErrorsSuite) TestCanWrapAll() {
+	err := errors.WrapAll(nil, errors.ArgumentMissing.With("name"), nil, errors.NotFound.With("user", "john"), nil)
+	suite.Require().NotNil(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+	suite.Assert().Equal("Argument name is missing\nCaused by:\n\tuser john Not Found", err.Error())
+	suite.Assert().Nil(errors.WrapErrors(nil, errors.ArgumentMissing.With("name"), nil), "WrapErrors should still be strict")
+}
+
+func (suite *ErrorsSuite) TestCanWrapAllWithOneError() {
+	err := errors.WrapAll(nil, errors.NotFound.With("user", "john"), nil)
+	suite.Require().NotNil(err)
+	suite.Assert().Equal("user john Not Found", err.Error())
+	suite.Assert().Nil(errors.WrapAll(nil, nil))
+	suite.Assert().Nil(errors.WrapAll())
+}
+
+func (suite *ErrorsSuite) TestCanWrapErrorsWithFrozenSentinels() {
+	errors.FreezeSentinels(true)
+	defer errors.FreezeSentinels(false)
+
+	for _, wrap := range []func(...error) error{errors.WrapErrors, errors.Join} {
+		err := wrap(errors.NotImplemented, fmt.Errorf("simple error"))
+		suite.Assert().NotPanics(func() { _ = err.(errors.Error).WithField("key", "value") })
+		suite.Assert().Nil(errors.NotImplemented.Cause, "the sentinel should not have changed")
+	}
+}